@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"service-common/services"
+)
+
+const commandUsage = `Usage:
+  hynix [-config file]... [-templates dir]
+                                Start the API server
+  hynix config validate [flags] Validate config.json (exit 1 on errors)
+  hynix config migrate [flags]  Rewrite legacy resource_calculation blocks as tiers
+  hynix image check [flags] image...
+                                Check that images exist in their registry (exit 1 if not)
+`
+
+// runCommand runs a CLI subcommand and returns the process exit code
+func runCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) >= 2 && args[0] == "config" {
+		switch args[1] {
+		case "validate":
+			return runConfigValidate(args[2:], stdout, stderr)
+		case "migrate":
+			return runConfigMigrate(args[2:], stdout, stderr)
+		}
+	}
+	if len(args) >= 2 && args[0] == "image" && args[1] == "check" {
+		return runImageCheck(args[2:], stdout, stderr)
+	}
+	fmt.Fprint(stderr, commandUsage)
+	return 2
+}
+
+// stringList is a repeatable string flag (-config base.json -config prod.json)
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// serverOptions holds the config and template locations of the API server
+type serverOptions struct {
+	configFiles []string
+	templateDir string
+}
+
+// parseServerOptions parses API server flags
+// CONFIG_FILES (comma-separated) and TEMPLATE_DIR are used when the flags are not given
+func parseServerOptions(args []string, stderr io.Writer) (*serverOptions, error) {
+	fs := flag.NewFlagSet("hynix", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var files stringList
+	fs.Var(&files, "config", "config file, repeat to deep-merge overlays in order (default: $CONFIG_FILES or "+services.DefaultConfigPath+")")
+	templates := fs.String("templates", defaultTemplateDir(), "template directory ($TEMPLATE_DIR)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprint(stderr, commandUsage)
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	if len(files) == 0 {
+		files = defaultConfigFiles()
+	}
+	return &serverOptions{configFiles: files, templateDir: *templates}, nil
+}
+
+// defaultConfigFiles returns CONFIG_FILES or the default config.json path
+func defaultConfigFiles() []string {
+	if files := services.SplitConfigFiles(os.Getenv("CONFIG_FILES")); len(files) > 0 {
+		return files
+	}
+	return []string{services.DefaultConfigPath}
+}
+
+// defaultTemplateDir returns TEMPLATE_DIR or the default template directory
+func defaultTemplateDir() string {
+	if dir := os.Getenv("TEMPLATE_DIR"); dir != "" {
+		return dir
+	}
+	return services.DefaultTemplateDir
+}
+
+// runConfigValidate validates config.json and prints every issue with its JSON path
+// Jenkins runs this before committing a rewritten config.json
+// Repeating -file validates the deep-merged result of all files (as the server would load it)
+func runConfigValidate(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var files stringList
+	fs.Var(&files, "file", "config file, repeat to validate merged overlays (default: $CONFIG_FILES or "+services.DefaultConfigPath+")")
+	templates := fs.String("templates", defaultTemplateDir(), "template directory (empty to skip template checks)")
+	format := fs.String("format", "text", "output format: text or json")
+	strict := fs.Bool("strict", false, "treat warnings as errors")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if len(files) == 0 {
+		files = defaultConfigFiles()
+	}
+	file := strings.Join(files, " + ")
+
+	_, issues := services.ValidateConfigFiles(files, *templates)
+	if issues == nil {
+		issues = services.ConfigIssues{}
+	}
+
+	errorCount, warningCount := 0, 0
+	for _, issue := range issues {
+		if issue.Severity == services.SeverityError {
+			errorCount++
+		} else {
+			warningCount++
+		}
+	}
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(map[string]interface{}{
+			"file":     file,
+			"valid":    errorCount == 0,
+			"errors":   errorCount,
+			"warnings": warningCount,
+			"issues":   issues,
+		})
+	case "text":
+		for _, issue := range issues {
+			fmt.Fprintln(stdout, issue)
+		}
+		fmt.Fprintf(stdout, "%s: %d error(s), %d warning(s)\n", file, errorCount, warningCount)
+	default:
+		fmt.Fprintf(stderr, "unknown format %q\n", *format)
+		return 2
+	}
+
+	if errorCount > 0 || (*strict && warningCount > 0) {
+		return 1
+	}
+	return 0
+}
+
+// runConfigMigrate converts legacy resource_calculation blocks (threshold/min_queue/max_queue)
+// to the two-tier format and rewrites config.json atomically
+func runConfigMigrate(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("config migrate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", services.DefaultConfigPath, "config.json path")
+	output := fs.String("output", "", "output path (default: overwrite -file)")
+	templates := fs.String("templates", defaultTemplateDir(), "template directory (empty to skip template checks)")
+	dryRun := fs.Bool("dry-run", false, "print the migrated config instead of writing it")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	data, migrated, issues := services.MigrateConfigFile(*file, *templates)
+	for _, issue := range issues {
+		fmt.Fprintln(stderr, issue)
+	}
+	if data == nil {
+		fmt.Fprintf(stderr, "%s: migration aborted, fix the errors above first\n", *file)
+		return 1
+	}
+
+	if *dryRun {
+		stdout.Write(data)
+		fmt.Fprintf(stderr, "%s: %d legacy provision(s) would be migrated %v\n", *file, len(migrated), migrated)
+		return 0
+	}
+
+	if len(migrated) == 0 && *output == "" {
+		fmt.Fprintf(stdout, "%s: no legacy resource_calculation found, nothing to do\n", *file)
+		return 0
+	}
+
+	target := *output
+	if target == "" {
+		target = *file
+	}
+	if err := services.WriteConfigFile(target, data); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", target, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s: migrated %d legacy provision(s) %v\n", target, len(migrated), migrated)
+	return 0
+}
+
+// runImageCheck checks images against their registries the same way SparkApplication creation does
+// Images of registries not listed with -registry are reported as skipped
+func runImageCheck(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("image check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var registries stringList
+	fs.Var(&registries, "registry", "registry to check, repeatable, http:// for plain HTTP (default: $IMAGE_CHECK_REGISTRIES or the registry of each image)")
+	authFile := fs.String("auth-file", os.Getenv("IMAGE_CHECK_AUTH_FILE"), "docker config.json with registry credentials ($IMAGE_CHECK_AUTH_FILE)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprint(stderr, commandUsage)
+		return 2
+	}
+
+	if len(registries) == 0 {
+		registries = services.SplitConfigFiles(os.Getenv("IMAGE_CHECK_REGISTRIES"))
+	}
+	if len(registries) == 0 {
+		for _, image := range fs.Args() {
+			if ref, err := services.ParseImageReference(image); err == nil {
+				registries = append(registries, ref.Registry)
+			}
+		}
+	}
+
+	checker, err := newImageChecker(registries, *authFile)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	failed := 0
+	for _, image := range fs.Args() {
+		var result services.ImageCheck
+		checked := false
+		if checker != nil {
+			result, checked = checker.Check(context.Background(), image)
+		}
+		switch {
+		case !checked:
+			fmt.Fprintf(stdout, "%s: skipped (registry not checked)\n", image)
+		case result.Status == services.ImageFound:
+			fmt.Fprintf(stdout, "%s: found\n", image)
+		default:
+			failed++
+			fmt.Fprintf(stdout, "%s: %s: %s\n", image, result.Status, result.Message)
+		}
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"service-common/logger"
+	"service-common/metrics"
+	"service-common/middleware"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// AdminUserKey - gin.Context에 저장하는 인증된 admin 사용자 이름 키
+	AdminUserKey = "admin_user"
+
+	// LogFieldAdminUser - 변경 로그의 사용자 이름 필드
+	LogFieldAdminUser = "admin_user"
+)
+
+// AdminTokens - admin API 토큰 → 사용자 이름
+type AdminTokens map[string]string
+
+// ParseAdminTokens - ADMIN_TOKENS 환경 변수 파싱
+// 형식: "name:token,name2:token2" (사용자 이름은 변경 로그에 기록됨)
+// 오류 메시지에는 토큰이 포함되지 않음 (형식 오류는 항목 순서, 중복은 사용자 이름만 표시)
+func ParseAdminTokens(value string) (AdminTokens, error) {
+	tokens := AdminTokens{}
+	for i, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, token, ok := strings.Cut(entry, ":")
+		if !ok || name == "" || token == "" {
+			// 항목이 토큰만으로 되어 있을 수 있으므로 로그에 남지 않도록 항목 내용 대신 순서만 표시
+			return nil, fmt.Errorf("ADMIN_TOKENS 항목 %d 형식 오류 (name:token)", i+1)
+		}
+		if _, exists := tokens[token]; exists {
+			return nil, fmt.Errorf("ADMIN_TOKENS에 같은 토큰이 중복되었습니다 (%s)", name)
+		}
+		tokens[token] = name
+	}
+	return tokens, nil
+}
+
+// AdminAuthMiddleware - admin API 인증 미들웨어 (Authorization: Bearer <token>)
+// 토큰이 하나도 설정되지 않았으면 admin API를 비활성화 (503 ADMIN_DISABLED)
+func AdminAuthMiddleware(tokens AdminTokens) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(tokens) == 0 {
+			metrics.RequestsTotal.WithLabelValues("", "admin", StatusError).Inc()
+			respondError(c, "", "admin", http.StatusServiceUnavailable, CodeAdminDisabled,
+				"admin API가 비활성화되어 있습니다 (ADMIN_TOKENS 미설정)", nil)
+			return
+		}
+
+		scheme, token, _ := strings.Cut(c.GetHeader("Authorization"), " ")
+		user, ok := "", false
+		if strings.EqualFold(scheme, "Bearer") && token != "" {
+			user, ok = tokens.lookup(token)
+		}
+		if !ok {
+			logger.Logger.Warn("admin API 인증 실패",
+				zap.String("path", c.Request.URL.Path),
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("request_id", middleware.GetRequestID(c)),
+			)
+			metrics.RequestsTotal.WithLabelValues("", "admin", StatusError).Inc()
+			c.Header("WWW-Authenticate", `Bearer realm="hynix-admin"`)
+			respondError(c, "", "admin", http.StatusUnauthorized, CodeUnauthorized, "유효한 admin 토큰이 필요합니다", nil)
+			return
+		}
+
+		c.Set(AdminUserKey, user)
+		c.Next()
+	}
+}
+
+// lookup - 토큰에 해당하는 사용자 이름 (타이밍으로 토큰을 추측할 수 없도록 모든 토큰과 비교)
+func (t AdminTokens) lookup(token string) (string, bool) {
+	user, found := "", false
+	for candidate, name := range t {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			user, found = name, true
+		}
+	}
+	return user, found
+}
+
+// adminUser - 인증된 admin 사용자 이름
+func adminUser(c *gin.Context) string {
+	return c.GetString(AdminUserKey)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAdminTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    AdminTokens
+		wantErr string
+	}{
+		{name: "empty", value: "", want: AdminTokens{}},
+		{name: "two tokens", value: "alice:token1, jenkins:token2,", want: AdminTokens{"token1": "alice", "token2": "jenkins"}},
+		{name: "bare secret", value: "alice:token1,s3cr3t-token", wantErr: "항목 2"},
+		{name: "missing name", value: ":s3cr3t-token", wantErr: "항목 1"},
+		{name: "missing token", value: "alice:", wantErr: "항목 1"},
+		{name: "duplicate token", value: "alice:s3cr3t-token,bob:s3cr3t-token", wantErr: "bob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAdminTokens(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want error mentioning %q", err, tt.wantErr)
+				}
+				if strings.Contains(err.Error(), "s3cr3t") {
+					t.Errorf("error %q leaks the token", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("tokens = %v, want %v", got, tt.want)
+			}
+			for token, name := range tt.want {
+				if got[token] != name {
+					t.Errorf("tokens[%s] = %q, want %q", token, got[token], name)
+				}
+			}
+		})
+	}
+}
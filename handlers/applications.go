@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"service-common/logger"
+	"service-common/metrics"
+	"service-common/services"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SSEHeartbeatInterval - SSE 스트림 연결 유지를 위한 heartbeat 전송 주기
+const SSEHeartbeatInterval = 15 * time.Second
+
+// ApplicationListResponse - SparkApplication 목록 응답
+type ApplicationListResponse struct {
+	Namespace  string                             `json:"namespace"`
+	Namespaces []string                           `json:"namespaces,omitempty"` // 여러 namespace를 조회한 경우 전체 목록
+	Selector   map[string]string                  `json:"selector"`
+	Items      []services.SparkApplicationSummary `json:"items"`
+}
+
+// GetSparkApplication - SparkApplication 단건 조회 핸들러
+// 이름은 ApplyServiceIDLabelsWithUIDToYAML과 동일한 규칙(service_id-category-uid)으로 결정
+// GET /api/v1/spark/applications/:service_id/:category/:uid?provision_id=0001_wfbm&region=icheon&namespace=default
+func GetSparkApplication(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	namespaces, name := resolveApplicationName(c)
+
+	namespace, summary, err := findSparkApplication(namespaces, name)
+	if err != nil {
+		handleApplicationError(c, startTime, "application_get", namespace, name, err)
+		return
+	}
+
+	logger.Logger.Info("SparkApplication 조회",
+		zap.String(LogFieldEndpoint, "application_get"),
+		zap.String(LogFieldNamespace, namespace),
+		zap.String(LogFieldResourceName, name),
+		zap.String("state", summary.State),
+	)
+	recordApplicationMetrics(summary.Labels[services.LabelProvisionID], "application_get", StatusSuccess, startTime)
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// DeleteSparkApplication - SparkApplication 삭제 핸들러
+// DELETE /api/v1/spark/applications/:service_id/:category/:uid?provision_id=0001_wfbm&region=icheon&namespace=default
+func DeleteSparkApplication(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	namespaces, name := resolveApplicationName(c)
+
+	namespace, _, err := findSparkApplication(namespaces, name)
+	if err != nil {
+		handleApplicationError(c, startTime, "application_delete", namespace, name, err)
+		return
+	}
+
+	summary, err := services.DeleteSparkApplication(namespace, name)
+	if err != nil {
+		handleApplicationError(c, startTime, "application_delete", namespace, name, err)
+		return
+	}
+
+	provisionID := summary.Labels[services.LabelProvisionID]
+	metrics.K8sDeletion.WithLabelValues(provisionID, namespace).Inc()
+
+	logger.Logger.Info("SparkApplication 삭제",
+		zap.String(LogFieldEndpoint, "application_delete"),
+		zap.String(LogFieldProvisionID, provisionID),
+		zap.String(LogFieldNamespace, namespace),
+		zap.String(LogFieldResourceName, name),
+		zap.String("state", summary.State),
+	)
+	recordApplicationMetrics(provisionID, "application_delete", StatusSuccess, startTime)
+
+	c.JSON(http.StatusOK, gin.H{
+		"name":      name,
+		"namespace": namespace,
+		"deleted":   true,
+		"state":     summary.State,
+	})
+}
+
+// ListSparkApplications - 라벨 기반 SparkApplication 목록 조회 핸들러
+// namespace를 지정하지 않으면 프로비저닝 override의 namespace를 모두 조회 (applicationNamespaces)
+// GET /api/v1/spark/applications?provision_id=0001_wfbm&category=tttm&build_number=13&build_track=canary&namespace=default
+func ListSparkApplications(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	provisionID := c.Query("provision_id")
+	namespaces := applicationNamespaces(c, provisionID, c.Query("category"), "")
+	namespace := namespaces[0]
+
+	selector := map[string]string{}
+	if provisionID != "" {
+		selector[services.LabelProvisionID] = provisionID
+	}
+	category := c.Query("category")
+	if category != "" {
+		selector[services.LabelCategory] = category
+	}
+	if buildNumber := c.Query("build_number"); buildNumber != "" {
+		selector[services.LabelBuildNumber] = buildNumberLabelValue(provisionID, category, buildNumber)
+	}
+	if buildTrack := c.Query("build_track"); buildTrack != "" {
+		selector[services.LabelBuildTrack] = buildTrack
+	}
+
+	items := []services.SparkApplicationSummary{}
+	for _, ns := range namespaces {
+		found, err := services.ListSparkApplications(ns, selector)
+		if err != nil {
+			handleApplicationError(c, startTime, "application_list", ns, "", err)
+			return
+		}
+		items = append(items, found...)
+	}
+
+	logger.Logger.Info("SparkApplication 목록 조회",
+		zap.String(LogFieldEndpoint, "application_list"),
+		zap.Strings(LogFieldNamespace, namespaces),
+		zap.Any("selector", selector),
+		zap.Int("count", len(items)),
+	)
+	recordApplicationMetrics(provisionID, "application_list", StatusSuccess, startTime)
+
+	response := ApplicationListResponse{
+		Namespace: namespace,
+		Selector:  selector,
+		Items:     items,
+	}
+	if len(namespaces) > 1 {
+		response.Namespaces = namespaces
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// StreamSparkApplicationEvents - SparkApplication 상태 전이 SSE 스트림 핸들러
+// SUBMITTED, RUNNING, COMPLETED, FAILED 등 상태가 바뀔 때마다 이벤트를 전송하고 종료 상태에서 스트림을 닫음
+// GET /api/v1/spark/applications/:service_id/:category/:uid/events?provision_id=0001_wfbm&region=icheon&namespace=default
+//
+// Events:
+//
+//	event: state      data: {"state": "RUNNING", "previous_state": "SUBMITTED", "driver_pod_name": "...", "timestamp": "..."}
+//	event: deleted    data: SparkApplication이 삭제됨 (스트림 종료)
+//	event: error      data: watch 실패 (스트림 종료)
+//	event: heartbeat  data: {"timestamp": "..."} (연결 유지용)
+func StreamSparkApplicationEvents(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	namespaces, name := resolveApplicationName(c)
+
+	namespace, _, err := findSparkApplication(namespaces, name)
+	if err != nil {
+		handleApplicationError(c, startTime, "application_events", namespace, name, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	events, err := services.WatchSparkApplication(ctx, namespace, name)
+	if err != nil {
+		handleApplicationError(c, startTime, "application_events", namespace, name, err)
+		return
+	}
+
+	logger.Logger.Info("SparkApplication 이벤트 스트림 시작",
+		zap.String(LogFieldEndpoint, "application_events"),
+		zap.String(LogFieldNamespace, namespace),
+		zap.String(LogFieldResourceName, name),
+	)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(SSEHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	lastState := ""
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-heartbeat.C:
+			c.SSEvent("heartbeat", gin.H{"timestamp": time.Now()})
+			return true
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			lastState = event.State
+			c.SSEvent(event.Type, event)
+			return !event.Terminal
+		}
+	})
+
+	logger.Logger.Info("SparkApplication 이벤트 스트림 종료",
+		zap.String(LogFieldEndpoint, "application_events"),
+		zap.String(LogFieldNamespace, namespace),
+		zap.String(LogFieldResourceName, name),
+		zap.String("last_state", lastState),
+		zap.Float64(LogFieldDurationMs, float64(time.Since(startTime).Milliseconds())),
+	)
+	recordApplicationMetrics("", "application_events", StatusSuccess, startTime)
+}
+
+// resolveApplicationName - 경로 파라미터에서 찾을 네임스페이스 후보와 SparkApplication 이름 결정
+// 서비스 아이디는 reference/create와 동일하게 _를 -로 정규화
+func resolveApplicationName(c *gin.Context) ([]string, string) {
+	namespaces := applicationNamespaces(c, c.Query("provision_id"), c.Param("category"), c.Query("region"))
+	return namespaces, services.SparkApplicationName(normalizeServiceID(c.Param("service_id")), c.Param("category"), c.Param("uid"))
+}
+
+// applicationNamespaces - 조회할 네임스페이스 후보
+// namespace 쿼리가 있으면 그 값만 사용하고, 없으면 렌더링과 같은 규칙으로 프로비저닝 override의 namespace를 사용
+func applicationNamespaces(c *gin.Context, provisionID, category, region string) []string {
+	if namespace := c.Query("namespace"); namespace != "" {
+		return []string{namespace}
+	}
+	config, err := services.LoadConfig()
+	if err != nil {
+		return []string{services.DefaultNamespace}
+	}
+	return services.ApplicationNamespaces(config, provisionID, category, region)
+}
+
+// findSparkApplication - 네임스페이스 후보를 순서대로 조회해 처음 찾은 SparkApplication 반환
+// 모든 후보에 없으면 첫 번째 네임스페이스와 NotFound 오류 반환
+func findSparkApplication(namespaces []string, name string) (string, *services.SparkApplicationSummary, error) {
+	var notFound error
+	for _, namespace := range namespaces {
+		summary, err := services.GetSparkApplication(namespace, name)
+		if err == nil {
+			return namespace, summary, nil
+		}
+		if !services.IsNotFoundError(err) {
+			return namespace, nil, err
+		}
+		if notFound == nil {
+			notFound = err
+		}
+	}
+	return namespaces[0], nil, notFound
+}
+
+// buildNumberLabelValue - build_number 필터 값을 build-number 라벨 값으로 변환
+// config.json의 빌드 번호(예: "13")가 주어지면 프로비저닝의 image.label_format(기본 "4.13.1")으로 변환하고
+// 그 외 값은 라벨 값 그대로 사용 (provision_id가 없으면 기본 형식)
+func buildNumberLabelValue(provisionID, category, buildNumber string) string {
+	if _, err := strconv.ParseUint(buildNumber, 10, 64); err != nil {
+		return buildNumber
+	}
+
+	var settings *services.ImageSettings
+	if provisionID != "" {
+		if config, err := services.LoadConfig(); err == nil {
+			if spec, err := services.FindProvisionConfig(config, provisionID); err == nil {
+				settings = services.ResolveProvision(spec, category, "").Spec.Image
+			}
+		}
+	}
+	return settings.Build(buildNumber, "").Label
+}
+
+// recordApplicationMetrics records lifecycle API metrics
+func recordApplicationMetrics(provisionID, endpoint, status string, startTime time.Time) {
+	metrics.RequestsTotal.WithLabelValues(provisionID, endpoint, status).Inc()
+	metrics.RequestDuration.WithLabelValues(provisionID, endpoint).Observe(time.Since(startTime).Seconds())
+}
+
+// handleApplicationError handles lifecycle API errors (존재하지 않으면 404, 연결 불가 503, 그 외 500)
+func handleApplicationError(c *gin.Context, startTime time.Time, endpoint, namespace, name string, err error) {
+	status, code := kubernetesErrorStatus(err)
+	message := "SparkApplication 처리 실패"
+	if services.IsNotFoundError(err) {
+		status = http.StatusNotFound
+		code = CodeApplicationNotFound
+		message = "SparkApplication을 찾을 수 없음"
+	}
+
+	logger.Logger.Error(message,
+		zap.String(LogFieldEndpoint, endpoint),
+		zap.String(LogFieldNamespace, namespace),
+		zap.String(LogFieldResourceName, name),
+		zap.Error(err),
+	)
+	recordApplicationMetrics("", endpoint, StatusError, startTime)
+	respondError(c, "", endpoint, status, code, fmt.Sprintf("%s: %v", message, err), gin.H{
+		"namespace": namespace,
+		"name":      name,
+	})
+}
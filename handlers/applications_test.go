@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"service-common/services"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testApplicationTemplate - SparkApplication 이름에 SERVICE_ID_PLACEHOLDER를 사용하는 최소 템플릿
+const testApplicationTemplate = `apiVersion: sparkoperator.k8s.io/v1beta2
+kind: SparkApplication
+metadata:
+  name: SERVICE_ID_PLACEHOLDER
+  namespace: default
+  labels:
+    build-number: "BUILD_NUMBER"
+spec:
+  type: Scala
+  mode: cluster
+  image: docker.io/library/spark:BUILD_NUMBER
+  driver:
+    podName: "SERVICE_ID_PLACEHOLDER"
+`
+
+// setupApplicationTest - 비활성화 프로비저닝 하나가 있는 설정/템플릿과 fake Kubernetes 클라이언트로 라우터 구성
+func setupApplicationTest(t *testing.T) *gin.Engine {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "0009_test.yaml"), []byte(testApplicationTemplate), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, "config.json")
+	config := `{"config_specs": [{"provision_id": "0009_test", "enabled": false,
+		"resource_calculation": {"minio": "bucket/<<service_id>>", "tiers": [{"name": "all", "queue": "default.all", "executor": 1}]},
+		"gang_scheduling": {"cpu": "1", "memory": "2"},
+		"build_number": {"number": "13"}}]}`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	previousTemplateDir, previousProvider := services.TemplateDir(), services.ActiveConfigProvider()
+	services.SetTemplateDir(dir)
+	store, err := services.NewConfigStore([]string{configPath}, nil)
+	if err != nil {
+		t.Fatalf("NewConfigStore: %v", err)
+	}
+	services.SetConfigProvider(store)
+	services.SetKubernetesClient(fake.NewClientBuilder().Build())
+	t.Cleanup(func() {
+		services.SetTemplateDir(previousTemplateDir)
+		services.SetConfigProvider(previousProvider)
+		services.SetKubernetesClient(nil)
+	})
+
+	router := gin.New()
+	router.POST("/spark/create", CreateSparkApplication)
+	router.GET("/spark/applications/:service_id/:category/:uid", GetSparkApplication)
+	router.DELETE("/spark/applications/:service_id/:category/:uid", DeleteSparkApplication)
+	return router
+}
+
+func serveApplicationRequest(router *gin.Engine, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDisabledProvisionApplicationLifecycle(t *testing.T) {
+	router := setupApplicationTest(t)
+
+	// 같은 service_id의 다른 uid는 서로 다른 SparkApplication으로 생성
+	for _, uid := range []string{"1", "2"} {
+		body := `{"provision_id": "0009_test", "service_id": "123_456", "category": "tttm", "region": "icheon", "uid": "` + uid + `"}`
+		rec := serveApplicationRequest(router, http.MethodPost, "/spark/create", body)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create uid %s = %d %s, want 201", uid, rec.Code, rec.Body)
+		}
+		var result services.CreateResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+		if want := "123-456-tttm-" + uid; result.Name != want {
+			t.Errorf("created name = %s, want %s", result.Name, want)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+	}{
+		{name: "get uid 1", method: http.MethodGet, target: "/spark/applications/123_456/tttm/1", wantStatus: http.StatusOK},
+		{name: "get uid 2", method: http.MethodGet, target: "/spark/applications/123_456/tttm/2", wantStatus: http.StatusOK},
+		{name: "get unknown uid", method: http.MethodGet, target: "/spark/applications/123_456/tttm/3", wantStatus: http.StatusNotFound},
+		{name: "delete uid 1", method: http.MethodDelete, target: "/spark/applications/123_456/tttm/1", wantStatus: http.StatusOK},
+		{name: "get deleted uid 1", method: http.MethodGet, target: "/spark/applications/123_456/tttm/1", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if rec := serveApplicationRequest(router, tt.method, tt.target, ""); rec.Code != tt.wantStatus {
+			t.Errorf("%s: %s %s = %d %s, want %d", tt.name, tt.method, tt.target, rec.Code, rec.Body, tt.wantStatus)
+		}
+	}
+}
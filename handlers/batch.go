@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"service-common/logger"
+	"service-common/metrics"
+	"service-common/middleware"
+	"service-common/services"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxBatchItems - 한 번의 batch 요청에서 허용하는 최대 항목 수
+	MaxBatchItems = 500
+
+	// DefaultBatchConcurrency - concurrency 미지정 시 기본 동시 처리 개수
+	DefaultBatchConcurrency = 4
+
+	// DefaultBatchMaxConcurrency - 요청에서 지정 가능한 최대 동시 처리 개수
+	DefaultBatchMaxConcurrency = 16
+)
+
+// GetSparkReferenceBatch - Batch reference 엔드포인트 핸들러
+// 여러 (provision_id, service_id, category, uid) 요청을 한 번에 렌더링
+// config.json과 템플릿은 batch 전체에서 한 번만 로드하고, MinIO 크기 계산은 worker 수만큼 병렬 처리
+// POST /api/v1/spark/reference/batch
+//
+// Environment:
+//
+//	BATCH_CONCURRENCY: 기본 동시 처리 개수 (default: 4)
+//	BATCH_MAX_CONCURRENCY: 요청에서 지정 가능한 최대 동시 처리 개수 (default: 16)
+func GetSparkReferenceBatch(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	var batchReq BatchReferenceRequest
+	if err := c.ShouldBindJSON(&batchReq); err != nil {
+		handleBatchError(c, startTime, http.StatusBadRequest, CodeInvalidRequest, "요청 본문 검증 실패", err)
+		return
+	}
+	if len(batchReq.Items) == 0 || len(batchReq.Items) > MaxBatchItems {
+		handleBatchError(c, startTime, http.StatusBadRequest, CodeInvalidRequest, "요청 항목 수 오류",
+			fmt.Errorf("items는 1개 이상 %d개 이하여야 합니다 (요청: %d개)", MaxBatchItems, len(batchReq.Items)))
+		return
+	}
+
+	concurrency := resolveBatchConcurrency(batchReq.Concurrency)
+	if wantsNoCache(c) {
+		for i := range batchReq.Items {
+			batchReq.Items[i].NoCache = true
+		}
+	}
+
+	logger.Logger.Info("Batch reference 요청 수신",
+		zap.String(LogFieldEndpoint, "reference_batch"),
+		zap.Int("item_count", len(batchReq.Items)),
+		zap.Int("concurrency", concurrency),
+	)
+
+	// config.json은 batch 전체에서 한 번만 로드
+	config, err := services.LoadConfig()
+	if err != nil {
+		handleBatchError(c, startTime, http.StatusInternalServerError, CodeConfigUnavailable, "설정 로드 실패", err)
+		return
+	}
+
+	// 템플릿은 provision_id별로 한 번만 로드
+	templates := loadBatchTemplates(batchReq.Items)
+
+	requestID := middleware.GetRequestID(c)
+	results := make([]BatchReferenceResult, len(batchReq.Items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range batchReq.Items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(index int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[index] = renderBatchItem(index, batchReq.Items[index], config, templates, requestID)
+		}(i)
+	}
+	wg.Wait()
+
+	response := BatchReferenceResponse{Results: results}
+	for _, result := range results {
+		if result.Error != nil {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+
+	logger.Logger.Info("Batch reference 처리 완료",
+		zap.String(LogFieldEndpoint, "reference_batch"),
+		zap.Int("item_count", len(results)),
+		zap.Int("succeeded", response.Succeeded),
+		zap.Int("failed", response.Failed),
+		zap.Float64(LogFieldDurationMs, float64(time.Since(startTime).Milliseconds())),
+	)
+	metrics.RequestsTotal.WithLabelValues("", "reference_batch", StatusSuccess).Inc()
+	metrics.RequestDuration.WithLabelValues("", "reference_batch").Observe(time.Since(startTime).Seconds())
+
+	c.JSON(http.StatusOK, response)
+}
+
+// batchTemplate - provision_id별 템플릿 로드 결과
+type batchTemplate struct {
+	yaml string
+	err  error
+}
+
+// loadBatchTemplates - batch 항목에 포함된 provision_id별 템플릿을 한 번씩 로드
+func loadBatchTemplates(items []ReferenceRequest) map[string]batchTemplate {
+	templates := make(map[string]batchTemplate)
+	for _, item := range items {
+		if item.ProvisionID == "" {
+			continue
+		}
+		if _, ok := templates[item.ProvisionID]; ok {
+			continue
+		}
+		yamlTemplate, err := services.LoadTemplateRaw(item.ProvisionID)
+		templates[item.ProvisionID] = batchTemplate{yaml: yamlTemplate, err: err}
+	}
+	return templates
+}
+
+// renderBatchItem - batch 항목 하나를 reference 파이프라인으로 렌더링
+func renderBatchItem(index int, req ReferenceRequest, config *services.Config, templates map[string]batchTemplate, requestID string) BatchReferenceResult {
+	itemStart := time.Now()
+
+	if err := validateReferenceRequest(&req); err != nil {
+		return newBatchErrorResult(index, &req, itemStart, requestID, CodeInvalidRequest, err)
+	}
+
+	template := templates[req.ProvisionID]
+	if template.err != nil {
+		return newBatchErrorResult(index, &req, itemStart, requestID, CodeTemplateMissing, fmt.Errorf("템플릿 로드 실패: %w", template.err))
+	}
+
+	provisionConfig, err := services.FindProvisionConfig(config, req.ProvisionID)
+	if err != nil {
+		return newBatchErrorResult(index, &req, itemStart, requestID, CodeProvisionNotFound, fmt.Errorf("프로비저닝 설정 찾기 실패: %w", err))
+	}
+
+	var yamlOutput string
+	var trace *DecisionTrace
+	if services.IsProvisionEnabled(provisionConfig) {
+		yamlOutput, trace, err = renderEnabledYAML("reference_batch", &req, provisionConfig, template.yaml, config.Revision)
+		if err != nil {
+			return newBatchErrorResult(index, &req, itemStart, requestID, CodeMinIOUnavailable, fmt.Errorf("MinIO 크기 조회 실패: %w", err))
+		}
+	} else {
+		yamlOutput, trace = renderDisabledYAML("reference_batch", &req, provisionConfig, template.yaml, config.Revision)
+	}
+
+	recordReferenceSuccessMetrics(req.ProvisionID, itemStart)
+
+	result := newBatchResult(index, &req)
+	result.YAML = yamlOutput
+	result.Trace = trace
+	return result
+}
+
+// newBatchResult - 요청 식별 필드를 채운 batch 결과 생성
+func newBatchResult(index int, req *ReferenceRequest) BatchReferenceResult {
+	return BatchReferenceResult{
+		Index:       index,
+		ProvisionID: req.ProvisionID,
+		ServiceID:   req.ServiceID,
+		Category:    req.Category,
+		UID:         req.UID,
+	}
+}
+
+// newBatchErrorResult - 실패한 batch 항목 결과 생성 및 로그/메트릭 기록
+func newBatchErrorResult(index int, req *ReferenceRequest, itemStart time.Time, requestID string, code ErrorCode, err error) BatchReferenceResult {
+	logger.Logger.Error("Batch 항목 렌더링 실패",
+		zap.String(LogFieldEndpoint, "reference_batch"),
+		zap.Int("index", index),
+		zap.String(LogFieldProvisionID, req.ProvisionID),
+		zap.String(LogFieldServiceID, req.ServiceID),
+		zap.String(LogFieldCategory, req.Category),
+		zap.Error(err),
+	)
+	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "reference", StatusError).Inc()
+	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "reference").Observe(time.Since(itemStart).Seconds())
+	metrics.ErrorsTotal.WithLabelValues(req.ProvisionID, "reference_batch", string(code)).Inc()
+
+	result := newBatchResult(index, req)
+	result.Error = &APIError{
+		Code:      code,
+		Message:   err.Error(),
+		RequestID: requestID,
+	}
+	return result
+}
+
+// resolveBatchConcurrency - 요청/환경 변수 기반 동시 처리 개수 결정
+func resolveBatchConcurrency(requested int) int {
+	maxConcurrency := services.GetEnvInt("BATCH_MAX_CONCURRENCY", DefaultBatchMaxConcurrency)
+	concurrency := services.GetEnvInt("BATCH_CONCURRENCY", DefaultBatchConcurrency)
+	if requested > 0 {
+		concurrency = requested
+	}
+	if concurrency > maxConcurrency {
+		concurrency = maxConcurrency
+	}
+	return concurrency
+}
+
+// handleBatchError handles batch-level errors
+func handleBatchError(c *gin.Context, startTime time.Time, status int, code ErrorCode, message string, err error) {
+	logger.Logger.Error(message,
+		zap.String(LogFieldEndpoint, "reference_batch"),
+		zap.Error(err),
+	)
+	metrics.RequestsTotal.WithLabelValues("", "reference_batch", StatusError).Inc()
+	metrics.RequestDuration.WithLabelValues("", "reference_batch").Observe(time.Since(startTime).Seconds())
+	respondError(c, "", "reference_batch", status, code, fmt.Sprintf("%s: %v", message, err), nil)
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"service-common/logger"
+	"service-common/services"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ListConfigRevisions - 설정 revision 목록 조회 핸들러 (최신순)
+// GET /api/v1/admin/config/revisions
+func ListConfigRevisions(history *services.ConfigHistory) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 요청 시작 시간 기록
+		startTime := time.Now()
+
+		var current int64
+		if config, err := services.LoadConfig(); err == nil {
+			current = config.Revision
+		}
+
+		recordApplicationMetrics("", "config_revision_list", StatusSuccess, startTime)
+		c.JSON(http.StatusOK, ConfigRevisionListResponse{Current: current, Items: history.List()})
+	}
+}
+
+// GetConfigRevision - 설정 revision 조회 핸들러 (적용된 설정 원문 포함)
+// GET /api/v1/admin/config/revisions/:revision
+func GetConfigRevision(history *services.ConfigHistory) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 요청 시작 시간 기록
+		startTime := time.Now()
+
+		revision, ok := revisionParam(c, startTime, "config_revision_get")
+		if !ok {
+			return
+		}
+
+		meta, data, err := history.Get(revision)
+		if err != nil {
+			handleConfigRevisionError(c, startTime, "config_revision_get", err)
+			return
+		}
+
+		recordApplicationMetrics("", "config_revision_get", StatusSuccess, startTime)
+		c.JSON(http.StatusOK, ConfigRevisionResponse{ConfigRevision: *meta, Config: json.RawMessage(data)})
+	}
+}
+
+// DiffConfigRevisions - 두 설정 revision의 unified diff 조회 핸들러
+// to를 생략하면 현재 적용 중인 revision과 비교
+// GET /api/v1/admin/config/diff?from=3&to=5
+func DiffConfigRevisions(history *services.ConfigHistory) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 요청 시작 시간 기록
+		startTime := time.Now()
+
+		from, err := strconv.ParseInt(c.Query("from"), 10, 64)
+		if err != nil {
+			recordApplicationMetrics("", "config_diff", StatusError, startTime)
+			respondError(c, "", "config_diff", http.StatusBadRequest, CodeInvalidRequest, "from은 revision 번호여야 합니다", nil)
+			return
+		}
+
+		var to int64
+		if value := c.Query("to"); value != "" {
+			if to, err = strconv.ParseInt(value, 10, 64); err != nil {
+				recordApplicationMetrics("", "config_diff", StatusError, startTime)
+				respondError(c, "", "config_diff", http.StatusBadRequest, CodeInvalidRequest, "to는 revision 번호여야 합니다", nil)
+				return
+			}
+		} else if config, err := services.LoadConfig(); err == nil {
+			to = config.Revision
+		}
+
+		diff, err := history.Diff(from, to)
+		if err != nil {
+			handleConfigRevisionError(c, startTime, "config_diff", err)
+			return
+		}
+
+		recordApplicationMetrics("", "config_diff", StatusSuccess, startTime)
+		c.JSON(http.StatusOK, diff)
+	}
+}
+
+// RollbackConfig - 설정 롤백 핸들러
+// revision 시점의 설정 원문을 다시 검증해 적용하며, 결과는 새 revision으로 기록됨
+// POST /api/v1/admin/config/revisions/:revision/rollback
+func RollbackConfig(history *services.ConfigHistory) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 요청 시작 시간 기록
+		startTime := time.Now()
+
+		revision, ok := revisionParam(c, startTime, "config_rollback")
+		if !ok {
+			return
+		}
+
+		applied, warnings, err := services.RollbackConfig(history, adminUser(c), revision)
+		if err != nil {
+			handleConfigRevisionError(c, startTime, "config_rollback", err)
+			return
+		}
+
+		logger.Logger.Info("설정 롤백",
+			zap.String(LogFieldEndpoint, "config_rollback"),
+			zap.String(LogFieldAdminUser, adminUser(c)),
+			zap.Int64("from_revision", revision),
+			zap.Int64("revision", applied.Revision),
+			zap.String("checksum", applied.Checksum),
+			zap.Float64(LogFieldDurationMs, float64(time.Since(startTime).Milliseconds())),
+		)
+		recordApplicationMetrics("", "config_rollback", StatusSuccess, startTime)
+		c.JSON(http.StatusOK, ConfigRollbackResponse{Revision: *applied, From: revision, Warnings: warnings})
+	}
+}
+
+// revisionParam - 경로의 revision 번호 파싱
+func revisionParam(c *gin.Context, startTime time.Time, endpoint string) (int64, bool) {
+	revision, err := strconv.ParseInt(c.Param("revision"), 10, 64)
+	if err != nil || revision < 1 {
+		recordApplicationMetrics("", endpoint, StatusError, startTime)
+		respondError(c, "", endpoint, http.StatusBadRequest, CodeInvalidRequest, "revision은 1 이상의 정수여야 합니다", nil)
+		return 0, false
+	}
+	return revision, true
+}
+
+// handleConfigRevisionError - 설정 revision API 오류를 상태 코드/오류 코드로 변환
+func handleConfigRevisionError(c *gin.Context, startTime time.Time, endpoint string, err error) {
+	status := http.StatusInternalServerError
+	code := CodeInternal
+	message := "설정 revision 처리 실패: " + err.Error()
+	var details interface{}
+
+	var invalidErr *services.ConfigInvalidError
+	switch {
+	case errors.Is(err, services.ErrRevisionNotFound):
+		status, code, message = http.StatusNotFound, CodeRevisionNotFound, err.Error()
+	case errors.As(err, &invalidErr):
+		// 템플릿 삭제 등으로 이전 설정이 현재 검증 규칙을 통과하지 못하는 경우
+		status, code, message = http.StatusUnprocessableEntity, CodeConfigInvalid, "롤백할 설정이 검증에 실패했습니다"
+		details = gin.H{"issues": invalidErr.Issues}
+	case errors.Is(err, services.ErrConfigReadOnly):
+		status, code, message = http.StatusConflict, CodeConfigReadOnly, err.Error()
+	}
+
+	logger.Logger.Warn(message,
+		zap.String(LogFieldEndpoint, endpoint),
+		zap.String(LogFieldAdminUser, adminUser(c)),
+		zap.String("code", string(code)),
+		zap.Error(err),
+	)
+	recordApplicationMetrics("", endpoint, StatusError, startTime)
+	respondError(c, "", endpoint, status, code, message, details)
+}
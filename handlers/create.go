@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"service-common/logger"
+	"service-common/metrics"
+	"service-common/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateSparkApplicationIdempotent - Idempotency-Key 헤더를 지원하는 Create 엔드포인트 핸들러
+// 같은 키로 재요청하면 렌더링/제출 없이 최초 결과를 반환 (보관 기간: IDEMPOTENCY_TTL, 기본 1h)
+// POST /api/v1/spark/create
+var CreateSparkApplicationIdempotent = withIdempotency("create", CreateSparkApplication)
+
+// CreateSparkApplication - Create 엔드포인트 핸들러
+// reference 엔드포인트와 동일한 렌더링 파이프라인으로 YAML을 생성한 뒤 SparkApplication CR을 생성
+// POST /api/v1/spark/create
+// POST /api/v1/spark/create?validate=true (생성 전 Kubernetes API dry-run 검증)
+//
+// Request body:
+//
+//	{"provision_id": "0001_wfbm", "service_id": "123456", "category": "tttm", "region": "icheon", "uid": "1",
+//	 "on_conflict": "reject"}
+//
+// on_conflict (같은 이름의 SparkApplication이 이미 있는 경우):
+//   - reject (기본값): 항상 409 반환
+//   - replace-if-terminal: 기존 애플리케이션이 종료 상태일 때만 server-side apply로 교체해 재실행, 실행 중이면 409
+//   - force: 실행 중이어도 server-side apply로 교체 (operator가 실행 중인 driver를 정리하고 재실행)
+//
+// 교체는 기존 리소스를 삭제하지 않으며, 변경할 수 없는 필드가 바뀐 경우에만 삭제 후 다시 생성 (recreated: true)
+//
+// Response:
+//
+//	201: {"name": "123456-tttm-1", "namespace": "default", "replaced": false, "build_version": "4.13.1", "build_track": "stable"}
+//	409: {"error": {"code": "APPLICATION_CONFLICT", "details": {"name": "123456-tttm-1", "state": "RUNNING", ...}, ...}}
+//	422: {"error": {"code": "VALIDATION_FAILED", "details": {"yaml": "...", "validation": {"valid": false, "errors": [...]}}, ...}}
+func CreateSparkApplication(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	// 요청 본문 파싱 및 필수 필드 검증
+	var createReq CreateRequest
+	if err := c.ShouldBindJSON(&createReq); err != nil {
+		handleCreateError(c, startTime, &createReq, http.StatusBadRequest, CodeInvalidRequest, "요청 본문 검증 실패", err)
+		return
+	}
+
+	req := createReq.toReferenceRequest()
+	req.NoCache = wantsNoCache(c)
+	if err := validateReferenceRequest(&req); err != nil {
+		handleCreateError(c, startTime, &createReq, http.StatusBadRequest, CodeInvalidRequest, "필수 파라미터 누락", err)
+		return
+	}
+	createReq.ServiceID = req.ServiceID
+
+	conflictPolicy, err := services.ParseConflictPolicy(createReq.OnConflict)
+	if err != nil {
+		handleCreateError(c, startTime, &createReq, http.StatusBadRequest, CodeInvalidRequest, "on_conflict 값 오류", err)
+		return
+	}
+
+	logCreateRequestReceived(&createReq)
+
+	// 1. 템플릿 YAML 로드
+	yamlTemplate, err := services.LoadTemplateRaw(req.ProvisionID)
+	if err != nil {
+		handleCreateError(c, startTime, &createReq, http.StatusNotFound, CodeTemplateMissing, "템플릿 로드 실패", err)
+		return
+	}
+
+	// 2. config.json 로드
+	config, err := services.LoadConfig()
+	if err != nil {
+		handleCreateError(c, startTime, &createReq, http.StatusInternalServerError, CodeConfigUnavailable, "설정 로드 실패", err)
+		return
+	}
+
+	// 3. 프로비저닝 ID에 해당하는 설정 찾기
+	provisionConfig, err := services.FindProvisionConfig(config, req.ProvisionID)
+	if err != nil {
+		handleCreateError(c, startTime, &createReq, http.StatusNotFound, CodeProvisionNotFound, "프로비저닝 설정 찾기 실패", err)
+		return
+	}
+
+	// 4. enabled 여부에 따라 reference와 동일하게 YAML 렌더링
+	var yamlOutput string
+	var trace *DecisionTrace
+	if services.IsProvisionEnabled(provisionConfig) {
+		yamlOutput, trace, err = renderEnabledYAML("create", &req, provisionConfig, yamlTemplate, config.Revision)
+		if err != nil {
+			handleCreateError(c, startTime, &createReq, http.StatusBadGateway, CodeMinIOUnavailable, "MinIO 크기 조회 실패", err)
+			return
+		}
+	} else {
+		yamlOutput, trace = renderDisabledYAML("create", &req, provisionConfig, yamlTemplate, config.Revision)
+	}
+
+	// 5. 레지스트리에 없는 이미지는 ImagePullBackOff가 되므로 제출하지 않음 (확인 실패는 렌더링 시 경고만 기록)
+	if missing := missingImages(trace.Images); len(missing) > 0 {
+		handleCreateImageMissing(c, startTime, &createReq, missing)
+		return
+	}
+
+	// 6. validate=true 이면 생성 전에 dry-run 검증
+	if wantsValidation(c) {
+		validation, err := validateRenderedYAML("create", &req, yamlOutput)
+		if err != nil {
+			status, code := kubernetesErrorStatus(err)
+			handleCreateError(c, startTime, &createReq, status, code, "Dry-run 검증 수행 실패", err)
+			return
+		}
+		if !validation.Valid {
+			handleCreateValidationFailed(c, startTime, &createReq, yamlOutput, validation)
+			return
+		}
+	}
+
+	// 7. SparkApplication CR 생성
+	result, err := services.CreateSparkApplicationCRFromYAML(yamlOutput, conflictPolicy)
+	if err != nil {
+		var conflictErr *services.ConflictError
+		if errors.As(err, &conflictErr) {
+			metrics.K8sCreation.WithLabelValues(req.ProvisionID, conflictErr.Namespace, "conflict").Inc()
+			handleCreateConflict(c, startTime, &createReq, conflictErr)
+			return
+		}
+		metrics.K8sCreation.WithLabelValues(req.ProvisionID, "", StatusError).Inc()
+		status, code := kubernetesErrorStatus(err)
+		handleCreateError(c, startTime, &createReq, status, code, "SparkApplication 생성 실패", err)
+		return
+	}
+
+	if result.Recreated {
+		metrics.K8sDeletion.WithLabelValues(req.ProvisionID, result.Namespace).Inc()
+	}
+	metrics.K8sCreation.WithLabelValues(req.ProvisionID, result.Namespace, StatusSuccess).Inc()
+
+	logCreateComplete(&createReq, result, startTime)
+	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "create", StatusSuccess).Inc()
+	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "create").Observe(time.Since(startTime).Seconds())
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// logCreateRequestReceived logs incoming create request
+func logCreateRequestReceived(req *CreateRequest) {
+	logger.Logger.Info("Create 요청 수신",
+		zap.String(LogFieldEndpoint, "create"),
+		zap.String(LogFieldProvisionID, req.ProvisionID),
+		zap.String(LogFieldServiceID, req.ServiceID),
+		zap.String(LogFieldCategory, req.Category),
+		zap.String(LogFieldRegion, req.Region),
+	)
+}
+
+// logCreateComplete logs successful SparkApplication creation
+func logCreateComplete(req *CreateRequest, result *services.CreateResult, startTime time.Time) {
+	logger.Logger.Info("SparkApplication 생성 완료",
+		zap.String(LogFieldEndpoint, "create"),
+		zap.String(LogFieldProvisionID, req.ProvisionID),
+		zap.String(LogFieldServiceID, req.ServiceID),
+		zap.String(LogFieldCategory, req.Category),
+		zap.String(LogFieldRegion, req.Region),
+		zap.String(LogFieldNamespace, result.Namespace),
+		zap.String(LogFieldResourceName, result.Name),
+		zap.Bool("replaced", result.Replaced),
+		zap.String("build_version", result.BuildVersion),
+		zap.String("build_track", result.BuildTrack),
+		zap.Float64(LogFieldDurationMs, float64(time.Since(startTime).Milliseconds())),
+	)
+}
+
+// handleCreateError handles create endpoint errors
+func handleCreateError(c *gin.Context, startTime time.Time, req *CreateRequest, status int, code ErrorCode, message string, err error) {
+	logger.Logger.Error(message,
+		zap.String(LogFieldEndpoint, "create"),
+		zap.String(LogFieldProvisionID, req.ProvisionID),
+		zap.String(LogFieldServiceID, req.ServiceID),
+		zap.String(LogFieldCategory, req.Category),
+		zap.String(LogFieldRegion, req.Region),
+		zap.Error(err),
+	)
+	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "create", StatusError).Inc()
+	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "create").Observe(time.Since(startTime).Seconds())
+	respondError(c, req.ProvisionID, "create", status, code, fmt.Sprintf("%s: %v", message, err), nil)
+}
+
+// handleCreateValidationFailed handles dry-run rejection (스키마/어드미션 웹훅 오류를 YAML과 함께 반환)
+func handleCreateValidationFailed(c *gin.Context, startTime time.Time, req *CreateRequest, yamlOutput string, validation *services.ValidationResult) {
+	logger.Logger.Error("Dry-run 검증 실패",
+		zap.String(LogFieldEndpoint, "create"),
+		zap.String(LogFieldProvisionID, req.ProvisionID),
+		zap.String(LogFieldServiceID, req.ServiceID),
+		zap.String(LogFieldCategory, req.Category),
+		zap.Strings("errors", validation.Errors),
+	)
+	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "create", StatusError).Inc()
+	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "create").Observe(time.Since(startTime).Seconds())
+	respondError(c, req.ProvisionID, "create", http.StatusUnprocessableEntity, CodeValidationFailed,
+		"Dry-run 검증 실패: SparkApplication이 Kubernetes API에서 거부되었습니다",
+		gin.H{
+			"yaml":       yamlOutput,
+			"validation": validation,
+		})
+}
+
+// handleCreateImageMissing handles images missing from the registry (422)
+func handleCreateImageMissing(c *gin.Context, startTime time.Time, req *CreateRequest, missing []services.ImageCheck) {
+	images := make([]string, len(missing))
+	for i, result := range missing {
+		images[i] = result.Image
+	}
+	logger.Logger.Error("레지스트리에 이미지 없음",
+		zap.String(LogFieldEndpoint, "create"),
+		zap.String(LogFieldProvisionID, req.ProvisionID),
+		zap.String(LogFieldServiceID, req.ServiceID),
+		zap.String(LogFieldCategory, req.Category),
+		zap.Strings("images", images),
+	)
+	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "create", StatusError).Inc()
+	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "create").Observe(time.Since(startTime).Seconds())
+	respondError(c, req.ProvisionID, "create", http.StatusUnprocessableEntity, CodeImageNotFound,
+		missing[0].Message, gin.H{"images": missing})
+}
+
+// handleCreateConflict handles conflicts with an existing SparkApplication (409)
+func handleCreateConflict(c *gin.Context, startTime time.Time, req *CreateRequest, conflictErr *services.ConflictError) {
+	logger.Logger.Warn("기존 SparkApplication과 충돌",
+		zap.String(LogFieldEndpoint, "create"),
+		zap.String(LogFieldProvisionID, req.ProvisionID),
+		zap.String(LogFieldServiceID, req.ServiceID),
+		zap.String(LogFieldCategory, req.Category),
+		zap.String(LogFieldNamespace, conflictErr.Namespace),
+		zap.String(LogFieldResourceName, conflictErr.Name),
+		zap.String("state", conflictErr.State),
+		zap.String("on_conflict", string(conflictErr.Policy)),
+	)
+	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "create", StatusError).Inc()
+	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "create").Observe(time.Since(startTime).Seconds())
+	respondError(c, req.ProvisionID, "create", http.StatusConflict, CodeApplicationConflict, conflictErr.Error(), gin.H{
+		"name":        conflictErr.Name,
+		"namespace":   conflictErr.Namespace,
+		"state":       conflictErr.State,
+		"on_conflict": conflictErr.Policy,
+	})
+}
@@ -7,10 +7,13 @@
 //   - health.go: Health check endpoint
 //   - create.go: Spark application creation endpoint
 //   - reference.go: Spark configuration reference endpoint
+//   - openapi.go: OpenAPI request validation middleware and spec endpoints
 //
 // Request Flow
 //
-// Each handler follows a consistent pattern:
+// Requests under /api/v1 are first validated against the OpenAPI document
+// (openapi/openapi.yaml) by OpenAPIValidationMiddleware. Each handler then
+// follows a consistent pattern:
 //   1. Record start time
 //   2. Parse and validate request
 //   3. Load templates and configuration
@@ -21,11 +24,19 @@
 //
 // Error Handling
 //
-// All errors are logged and returned as JSON responses with appropriate
+// All errors are logged and returned through respondError as a common JSON
+// envelope with a stable error code (see errors.go), so clients can branch on
+// the code instead of the message:
+//
+//	{"error": {"code": "PROVISION_NOT_FOUND", "message": "...", "details": {...}, "request_id": "..."}}
+//
 // HTTP status codes:
-//   - 400: Bad Request (validation errors)
-//   - 404: Not Found (template/config not found)
-//   - 500: Internal Server Error (processing failures)
+//   - 400: Bad Request (INVALID_REQUEST)
+//   - 404: Not Found (TEMPLATE_MISSING, PROVISION_NOT_FOUND, APPLICATION_NOT_FOUND)
+//   - 409: Conflict (APPLICATION_CONFLICT, IDEMPOTENCY_IN_PROGRESS)
+//   - 422: Unprocessable Entity (VALIDATION_FAILED, IDEMPOTENCY_KEY_REUSED)
+//   - 500: Internal Server Error (CONFIG_UNAVAILABLE, KUBERNETES_ERROR, INTERNAL_ERROR)
+//   - 502/503: Upstream unavailable (MINIO_UNAVAILABLE, KUBERNETES_UNAVAILABLE)
 //
 // Metrics
 //
@@ -35,6 +46,7 @@
 //   - k8s_creation_total: Kubernetes CR creation attempts
 //   - provision_mode: Provision mode (enabled/disabled)
 //   - queue_selection: Selected queue for resource allocation
+//   - errors_total: Error responses by stable error code
 //
 // Constants
 //
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+	"service-common/metrics"
+	"service-common/middleware"
+	"service-common/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorCode - 클라이언트가 분기할 수 있는 안정적인 오류 코드
+// 메시지는 변경될 수 있으므로 클라이언트는 code로만 판단해야 함
+type ErrorCode string
+
+const (
+	// CodeInvalidRequest - 요청 형식/필수 파라미터 오류 (400)
+	CodeInvalidRequest ErrorCode = "INVALID_REQUEST"
+	// CodeProvisionNotFound - config에 프로비저닝 ID가 없음 (404)
+	CodeProvisionNotFound ErrorCode = "PROVISION_NOT_FOUND"
+	// CodeTemplateMissing - 프로비저닝 ID의 템플릿 파일이 없음 (404)
+	CodeTemplateMissing ErrorCode = "TEMPLATE_MISSING"
+	// CodeConfigUnavailable - config 로드/파싱 실패 (500)
+	CodeConfigUnavailable ErrorCode = "CONFIG_UNAVAILABLE"
+	// CodeMinIOUnavailable - MinIO 크기 조회 실패 (502)
+	CodeMinIOUnavailable ErrorCode = "MINIO_UNAVAILABLE"
+	// CodeValidationFailed - Kubernetes API dry-run 검증에서 거부됨 (422)
+	CodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	// CodeImageNotFound - 렌더링된 image 태그/digest가 레지스트리에 없음 (422, details.images)
+	CodeImageNotFound ErrorCode = "IMAGE_NOT_FOUND"
+	// CodeKubernetesUnavailable - Kubernetes 클라이언트 초기화 실패 또는 API 서버에 연결할 수 없음 (503)
+	CodeKubernetesUnavailable ErrorCode = "KUBERNETES_UNAVAILABLE"
+	// CodeKubernetesError - Kubernetes API 요청 실패 (500)
+	CodeKubernetesError ErrorCode = "KUBERNETES_ERROR"
+	// CodeApplicationNotFound - SparkApplication이 존재하지 않음 (404)
+	CodeApplicationNotFound ErrorCode = "APPLICATION_NOT_FOUND"
+	// CodeApplicationConflict - 같은 이름의 SparkApplication이 이미 존재 (409)
+	CodeApplicationConflict ErrorCode = "APPLICATION_CONFLICT"
+	// CodeIdempotencyInProgress - 같은 Idempotency-Key 요청이 처리 중 (409)
+	CodeIdempotencyInProgress ErrorCode = "IDEMPOTENCY_IN_PROGRESS"
+	// CodeIdempotencyKeyReused - Idempotency-Key가 다른 쿼리/요청 본문으로 재사용됨 (422)
+	CodeIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	// CodeUnauthorized - admin API 인증 실패 (401)
+	CodeUnauthorized ErrorCode = "UNAUTHORIZED"
+	// CodeAdminDisabled - ADMIN_TOKENS가 설정되지 않아 admin API가 비활성화됨 (503)
+	CodeAdminDisabled ErrorCode = "ADMIN_DISABLED"
+	// CodeProvisionExists - 생성하려는 provision_id가 이미 있음 (409)
+	CodeProvisionExists ErrorCode = "PROVISION_EXISTS"
+	// CodeConfigReadOnly - 현재 설정 출처는 API로 변경할 수 없음 (409)
+	CodeConfigReadOnly ErrorCode = "CONFIG_READ_ONLY"
+	// CodeConfigInvalid - 변경 결과가 설정 검증에 실패 (422, details.issues)
+	CodeConfigInvalid ErrorCode = "CONFIG_INVALID"
+	// CodePreconditionFailed - If-Match가 현재 ETag와 다름 (412)
+	CodePreconditionFailed ErrorCode = "PRECONDITION_FAILED"
+	// CodePreconditionRequired - 변경 요청에 If-Match 헤더가 없음 (428)
+	CodePreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
+	// CodeCanaryNotActive - promote/abort할 카나리 빌드가 없음 (409)
+	CodeCanaryNotActive ErrorCode = "CANARY_NOT_ACTIVE"
+	// CodeRevisionNotFound - 설정 revision이 없음 (보관 개수를 넘어 삭제되었을 수 있음) (404)
+	CodeRevisionNotFound ErrorCode = "REVISION_NOT_FOUND"
+	// CodeNotFound - 등록되지 않은 경로 (404)
+	CodeNotFound ErrorCode = "NOT_FOUND"
+	// CodeInternal - 기타 내부 오류 (500)
+	CodeInternal ErrorCode = "INTERNAL_ERROR"
+)
+
+// APIError - 공통 오류 응답 본문
+type APIError struct {
+	Code      ErrorCode   `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// ErrorResponse - 모든 핸들러의 오류 응답 envelope
+//
+//	{"error": {"code": "PROVISION_NOT_FOUND", "message": "...", "details": {...}, "request_id": "..."}}
+type ErrorResponse struct {
+	Error *APIError `json:"error"`
+}
+
+// newAPIError - 요청 ID를 포함한 APIError 생성
+func newAPIError(c *gin.Context, code ErrorCode, message string, details interface{}) *APIError {
+	return &APIError{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: middleware.GetRequestID(c),
+	}
+}
+
+// respondError - 오류 코드 메트릭 기록 후 공통 envelope으로 응답
+func respondError(c *gin.Context, provisionID, endpoint string, status int, code ErrorCode, message string, details interface{}) {
+	metrics.ErrorsTotal.WithLabelValues(provisionID, endpoint, string(code)).Inc()
+	c.AbortWithStatusJSON(status, ErrorResponse{
+		Error: newAPIError(c, code, message, details),
+	})
+}
+
+// kubernetesErrorStatus - Kubernetes 요청 오류의 상태 코드/오류 코드 (연결 불가는 503, 그 외 500)
+// Kubernetes를 호출하는 모든 핸들러가 같은 기준으로 응답하도록 사용
+func kubernetesErrorStatus(err error) (int, ErrorCode) {
+	if services.IsKubernetesUnavailable(err) {
+		return http.StatusServiceUnavailable, CodeKubernetesUnavailable
+	}
+	return http.StatusInternalServerError, CodeKubernetesError
+}
+
+// NotFound - 등록되지 않은 경로 핸들러
+func NotFound(c *gin.Context) {
+	respondError(c, "", "unknown", http.StatusNotFound, CodeNotFound, "존재하지 않는 경로입니다: "+c.Request.URL.Path, nil)
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"service-common/logger"
+	"service-common/metrics"
+	"service-common/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader - 멱등 제출을 위한 요청 헤더
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotencyReplayedHeader - 저장된 결과를 반환한 경우 응답 헤더
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	// MaxIdempotencyKeyLength - Idempotency-Key 최대 길이
+	MaxIdempotencyKeyLength = 255
+
+	// DefaultIdempotencyTTL - 처리 결과 보관 기간 기본값 (IDEMPOTENCY_TTL 환경 변수로 변경)
+	DefaultIdempotencyTTL = time.Hour
+)
+
+// idempotencyStore - create 엔드포인트의 Idempotency-Key 결과 저장소 (main에서 IDEMPOTENCY_TTL로 교체)
+var idempotencyStore = services.NewIdempotencyStore(DefaultIdempotencyTTL)
+
+// SetIdempotencyStore - Idempotency-Key 결과 저장소 지정 (서버 시작 전에 호출)
+func SetIdempotencyStore(store *services.IdempotencyStore) {
+	idempotencyStore = store
+}
+
+// bodyCaptureWriter - 응답 본문을 함께 기록하는 ResponseWriter
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+// Write - 클라이언트 응답과 동시에 본문 기록
+func (w *bodyCaptureWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// withIdempotency - Idempotency-Key 헤더가 있으면 최초 결과를 기억하고 재요청 시 그대로 반환
+// 헤더가 없으면 handler를 그대로 실행
+// 2xx/4xx 결과는 TTL 동안 보관하고, 5xx(일시적 오류)는 보관하지 않아 재시도 가능
+func withIdempotency(endpoint string, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			handler(c)
+			return
+		}
+		if len(key) > MaxIdempotencyKeyLength {
+			respondError(c, "", endpoint, http.StatusBadRequest, CodeInvalidRequest,
+				fmt.Sprintf("Idempotency-Key는 %d자 이하여야 합니다", MaxIdempotencyKeyLength), nil)
+			return
+		}
+
+		// 쿼리 문자열과 요청 본문 해시로 같은 키의 다른 요청 구분 (본문은 handler에서 다시 읽을 수 있도록 복원)
+		body, err := c.GetRawData()
+		if err != nil {
+			respondError(c, "", endpoint, http.StatusBadRequest, CodeInvalidRequest,
+				fmt.Sprintf("요청 본문 읽기 실패: %v", err), nil)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		storeKey := endpoint + ":" + key
+		stored, err := idempotencyStore.Begin(storeKey, requestFingerprint(c.Request.URL.RawQuery, body))
+		if err != nil {
+			handleIdempotencyError(c, endpoint, key, err)
+			return
+		}
+		if stored != nil {
+			replayIdempotentResponse(c, endpoint, key, stored)
+			return
+		}
+
+		writer := &bodyCaptureWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+
+		completed := false
+		defer func() {
+			// handler가 결과를 남기지 못한 경우(panic 등) 키 해제
+			if !completed {
+				idempotencyStore.Abort(storeKey)
+			}
+		}()
+
+		handler(c)
+
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+		idempotencyStore.Complete(storeKey, &services.IdempotentResponse{
+			StatusCode:  writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+		completed = true
+	}
+}
+
+// requestFingerprint - 쿼리 문자열(validate, on_conflict 등)과 본문을 함께 해시
+// 같은 본문이라도 쿼리가 다르면 다른 요청으로 판단
+func requestFingerprint(rawQuery string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(rawQuery))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replayIdempotentResponse - 저장된 최초 결과를 재렌더링/재제출 없이 반환
+func replayIdempotentResponse(c *gin.Context, endpoint, key string, stored *services.IdempotentResponse) {
+	logger.Logger.Info("Idempotency-Key 재요청 - 저장된 결과 반환",
+		zap.String(LogFieldEndpoint, endpoint),
+		zap.String("idempotency_key", key),
+		zap.Int("status", stored.StatusCode),
+	)
+	metrics.IdempotencyHits.WithLabelValues(endpoint).Inc()
+
+	c.Header(IdempotencyReplayedHeader, "true")
+	c.Data(stored.StatusCode, stored.ContentType, stored.Body)
+}
+
+// handleIdempotencyError handles in-progress or reused Idempotency-Key requests
+func handleIdempotencyError(c *gin.Context, endpoint, key string, err error) {
+	status := http.StatusConflict
+	code := CodeIdempotencyInProgress
+	if errors.Is(err, services.ErrIdempotencyKeyReused) {
+		status = http.StatusUnprocessableEntity
+		code = CodeIdempotencyKeyReused
+	}
+
+	logger.Logger.Warn("Idempotency-Key 처리 거부",
+		zap.String(LogFieldEndpoint, endpoint),
+		zap.String("idempotency_key", key),
+		zap.Error(err),
+	)
+	respondError(c, "", endpoint, status, code, err.Error(), gin.H{
+		"idempotency_key": key,
+	})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"service-common/logger"
+	"service-common/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	logger.Logger = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+// idempotencyRequest - 테스트 라우터로 보낼 요청
+type idempotencyRequest struct {
+	key   string
+	query string
+	body  string
+}
+
+// newIdempotencyRouter - status를 응답하고 호출 횟수를 세는 handler를 withIdempotency로 감싼 라우터
+func newIdempotencyRouter(status int, calls *int) *gin.Engine {
+	router := gin.New()
+	router.POST("/create", withIdempotency("create", func(c *gin.Context) {
+		*calls++
+		c.JSON(status, gin.H{"call": *calls})
+	}))
+	return router
+}
+
+func sendIdempotencyRequest(router *gin.Engine, req idempotencyRequest) *httptest.ResponseRecorder {
+	target := "/create"
+	if req.query != "" {
+		target += "?" + req.query
+	}
+	httpReq := httptest.NewRequest(http.MethodPost, target, strings.NewReader(req.body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	if req.key != "" {
+		httpReq.Header.Set(IdempotencyKeyHeader, req.key)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httpReq)
+	return rec
+}
+
+func TestWithIdempotency(t *testing.T) {
+	first := idempotencyRequest{key: "k1", query: "validate=true", body: `{"uid":"u1"}`}
+
+	tests := []struct {
+		name         string
+		status       int
+		second       idempotencyRequest
+		wantStatus   int
+		wantCode     ErrorCode
+		wantReplayed bool
+		wantCalls    int
+	}{
+		{
+			name:         "same key and request replays first result",
+			status:       http.StatusCreated,
+			second:       first,
+			wantStatus:   http.StatusCreated,
+			wantReplayed: true,
+			wantCalls:    1,
+		},
+		{
+			name:         "client errors are stored",
+			status:       http.StatusBadRequest,
+			second:       first,
+			wantStatus:   http.StatusBadRequest,
+			wantReplayed: true,
+			wantCalls:    1,
+		},
+		{
+			name:       "different body is rejected",
+			status:     http.StatusCreated,
+			second:     idempotencyRequest{key: "k1", query: first.query, body: `{"uid":"u2"}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   CodeIdempotencyKeyReused,
+			wantCalls:  1,
+		},
+		{
+			name:       "different query is rejected",
+			status:     http.StatusCreated,
+			second:     idempotencyRequest{key: "k1", body: first.body},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   CodeIdempotencyKeyReused,
+			wantCalls:  1,
+		},
+		{
+			name:       "server errors are not stored",
+			status:     http.StatusServiceUnavailable,
+			second:     first,
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  2,
+		},
+		{
+			name:       "different key runs again",
+			status:     http.StatusCreated,
+			second:     idempotencyRequest{key: "k2", query: first.query, body: first.body},
+			wantStatus: http.StatusCreated,
+			wantCalls:  2,
+		},
+		{
+			name:       "no key runs again",
+			status:     http.StatusCreated,
+			second:     idempotencyRequest{query: first.query, body: first.body},
+			wantStatus: http.StatusCreated,
+			wantCalls:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetIdempotencyStore(services.NewIdempotencyStore(time.Hour))
+			calls := 0
+			router := newIdempotencyRouter(tt.status, &calls)
+
+			firstRec := sendIdempotencyRequest(router, first)
+			if firstRec.Code != tt.status {
+				t.Fatalf("first status = %d, want %d", firstRec.Code, tt.status)
+			}
+
+			rec := sendIdempotencyRequest(router, tt.second)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("second status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantCode != "" && !strings.Contains(rec.Body.String(), string(tt.wantCode)) {
+				t.Errorf("second body = %s, want code %s", rec.Body.String(), tt.wantCode)
+			}
+			if replayed := rec.Header().Get(IdempotencyReplayedHeader) == "true"; replayed != tt.wantReplayed {
+				t.Errorf("replayed = %v, want %v", replayed, tt.wantReplayed)
+			}
+			if tt.wantReplayed && rec.Body.String() != firstRec.Body.String() {
+				t.Errorf("replayed body = %s, want %s", rec.Body.String(), firstRec.Body.String())
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWithIdempotencyKeyTooLong(t *testing.T) {
+	SetIdempotencyStore(services.NewIdempotencyStore(time.Hour))
+	calls := 0
+	router := newIdempotencyRouter(http.StatusCreated, &calls)
+
+	rec := sendIdempotencyRequest(router, idempotencyRequest{key: strings.Repeat("k", MaxIdempotencyKeyLength+1), body: "{}"})
+	if rec.Code != http.StatusBadRequest || calls != 0 {
+		t.Fatalf("status = %d, calls = %d, want 400 and no handler call", rec.Code, calls)
+	}
+}
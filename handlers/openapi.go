@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"service-common/logger"
+	"service-common/metrics"
+	"service-common/middleware"
+	"service-common/openapi"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// OpenAPIValidationMiddleware - 요청을 OpenAPI 문서에 대해 검증하는 미들웨어
+// 필수 파라미터 누락, 허용되지 않은 provision_id, UID 형식 오류 등은 핸들러에 도달하기 전에
+// 400 INVALID_REQUEST로 응답 (details.errors에 필드별 오류 목록)
+// 문서에 없는 경로(health, metrics 등)는 검증하지 않음
+func OpenAPIValidationMiddleware(validator *openapi.Validator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 요청 시작 시간 기록
+		startTime := time.Now()
+
+		endpoint, fieldErrors, matched := validator.ValidateRequest(c.Request)
+		if !matched || len(fieldErrors) == 0 {
+			c.Next()
+			return
+		}
+
+		// provision_id가 검증 대상이므로 메트릭 라벨에는 사용하지 않음 (카디널리티 제한)
+		logger.Logger.Warn("OpenAPI 요청 검증 실패",
+			zap.String(LogFieldEndpoint, endpoint),
+			zap.String("request_id", middleware.GetRequestID(c)),
+			zap.Any("errors", fieldErrors),
+		)
+		metrics.RequestsTotal.WithLabelValues("", endpoint, StatusError).Inc()
+		metrics.RequestDuration.WithLabelValues("", endpoint).Observe(time.Since(startTime).Seconds())
+		first := fieldErrors[0]
+		message := "요청이 API 명세와 일치하지 않습니다: " + first.Reason
+		if first.Field != "" {
+			message = "요청이 API 명세와 일치하지 않습니다: " + first.Field + ": " + first.Reason
+		}
+		respondError(c, "", endpoint, http.StatusBadRequest, CodeInvalidRequest, message, gin.H{"errors": fieldErrors})
+	}
+}
+
+// OpenAPISpecHandler - OpenAPI 문서 제공 핸들러
+// GET /api/v1/openapi.yaml
+// GET /api/v1/openapi.json
+func OpenAPISpecHandler(validator *openapi.Validator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.HasSuffix(c.Request.URL.Path, ".json") {
+			c.Data(http.StatusOK, "application/json", validator.SpecJSON())
+			return
+		}
+		c.Data(http.StatusOK, "application/x-yaml", openapi.SpecYAML())
+	}
+}
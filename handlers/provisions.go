@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"service-common/logger"
+	"service-common/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ListProvisions - 프로비저닝 설정 목록 조회 핸들러
+// GET /api/v1/admin/provisions
+func ListProvisions(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	config, err := services.LoadConfig()
+	if err != nil {
+		recordApplicationMetrics("", "provision_list", StatusError, startTime)
+		respondError(c, "", "provision_list", http.StatusInternalServerError, CodeConfigUnavailable, "설정 로드 실패: "+err.Error(), nil)
+		return
+	}
+
+	items := make([]ProvisionResource, 0, len(config.ConfigSpecs))
+	for i := range config.ConfigSpecs {
+		spec := &config.ConfigSpecs[i]
+		items = append(items, ProvisionResource{ETag: services.ProvisionETag(spec), Spec: spec})
+	}
+
+	source := ""
+	if provider := services.ActiveConfigProvider(); provider != nil {
+		source = provider.Source()
+	}
+	recordApplicationMetrics("", "provision_list", StatusSuccess, startTime)
+	c.JSON(http.StatusOK, ProvisionListResponse{Source: source, Items: items})
+}
+
+// GetProvision - 프로비저닝 설정 조회 핸들러 (ETag 헤더 포함)
+// GET /api/v1/admin/provisions/:provision_id
+func GetProvision(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	provisionID := c.Param("provision_id")
+	config, err := services.LoadConfig()
+	if err != nil {
+		recordApplicationMetrics(provisionID, "provision_get", StatusError, startTime)
+		respondError(c, provisionID, "provision_get", http.StatusInternalServerError, CodeConfigUnavailable, "설정 로드 실패: "+err.Error(), nil)
+		return
+	}
+	spec, err := services.FindProvisionConfig(config, provisionID)
+	if err != nil {
+		handleProvisionError(c, startTime, "provision_get", provisionID, services.ErrProvisionNotFound)
+		return
+	}
+
+	recordApplicationMetrics(provisionID, "provision_get", StatusSuccess, startTime)
+	respondProvision(c, http.StatusOK, spec, nil)
+}
+
+// CreateProvision - 프로비저닝 추가 핸들러
+// 검증을 통과한 경우에만 config.json에 기록되며, 실행 중인 서비스에 바로 적용됨
+// POST /api/v1/admin/provisions
+func CreateProvision(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	spec, ok := bindProvisionSpec(c, startTime, "provision_create", "")
+	if !ok {
+		return
+	}
+
+	created, warnings, err := services.CreateProvision(adminUser(c), spec)
+	if err != nil {
+		handleProvisionError(c, startTime, "provision_create", spec.ProvisionID, err)
+		return
+	}
+
+	logProvisionChange(c, "provision_create", created, startTime)
+	recordApplicationMetrics(created.ProvisionID, "provision_create", StatusSuccess, startTime)
+	c.Header("Location", c.Request.URL.Path+"/"+created.ProvisionID)
+	respondProvision(c, http.StatusCreated, created, warnings)
+}
+
+// UpdateProvision - 프로비저닝 설정 교체 핸들러
+// 다른 사용자의 변경을 덮어쓰지 않도록 If-Match(GET 응답의 ETag)가 필수
+// PUT /api/v1/admin/provisions/:provision_id
+func UpdateProvision(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	provisionID := c.Param("provision_id")
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		recordApplicationMetrics(provisionID, "provision_update", StatusError, startTime)
+		respondError(c, provisionID, "provision_update", http.StatusPreconditionRequired, CodePreconditionRequired,
+			"If-Match 헤더가 필요합니다 (GET 응답의 ETag)", nil)
+		return
+	}
+
+	spec, ok := bindProvisionSpec(c, startTime, "provision_update", provisionID)
+	if !ok {
+		return
+	}
+
+	updated, warnings, err := services.ReplaceProvision(adminUser(c), provisionID, ifMatch, spec)
+	if err != nil {
+		handleProvisionError(c, startTime, "provision_update", provisionID, err)
+		return
+	}
+
+	logProvisionChange(c, "provision_update", updated, startTime)
+	recordApplicationMetrics(provisionID, "provision_update", StatusSuccess, startTime)
+	respondProvision(c, http.StatusOK, updated, warnings)
+}
+
+// DisableProvision - 프로비저닝 비활성화 핸들러 (enabled=false, 설정은 삭제하지 않음)
+// If-Match가 있으면 현재 ETag와 일치하는 경우에만 변경
+// POST /api/v1/admin/provisions/:provision_id/disable
+func DisableProvision(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	provisionID := c.Param("provision_id")
+	disabled, warnings, err := services.DisableProvision(adminUser(c), provisionID, c.GetHeader("If-Match"))
+	if err != nil {
+		handleProvisionError(c, startTime, "provision_disable", provisionID, err)
+		return
+	}
+
+	logProvisionChange(c, "provision_disable", disabled, startTime)
+	recordApplicationMetrics(provisionID, "provision_disable", StatusSuccess, startTime)
+	respondProvision(c, http.StatusOK, disabled, warnings)
+}
+
+// SetProvisionBuild - CI webhook용 빌드 번호 설정 핸들러
+// config.json을 git으로 수정하지 않고 설정 저장소에 검증 후 기록하며, 변경한 사용자(토큰 이름)와 시각을 build_number에 남김
+// canary를 지정하면 안정 빌드는 그대로 두고 카나리 빌드로 설정
+// If-Match가 있으면 현재 ETag와 일치하는 경우에만 변경
+// POST /api/v1/admin/provisions/:provision_id/build
+//
+// Request body:
+//
+//	{"number": "14", "digest": "sha256:...", "updated_by": "jenkins/hynix-build#14", "canary": {"percent": 10, "categories": ["tttm"]}}
+func SetProvisionBuild(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	provisionID := c.Param("provision_id")
+	var req BuildUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		recordApplicationMetrics(provisionID, "provision_build", StatusError, startTime)
+		respondError(c, provisionID, "provision_build", http.StatusBadRequest, CodeInvalidRequest, "요청 본문 검증 실패: "+err.Error(), nil)
+		return
+	}
+
+	var canary *services.BuildCanary
+	if req.Canary != nil {
+		canary = &services.BuildCanary{Percent: req.Canary.Percent, Categories: req.Canary.Categories}
+	}
+	updated, warnings, err := services.SetBuild(adminUser(c), req.UpdatedBy, provisionID, c.GetHeader("If-Match"), req.Number, req.Digest, canary)
+	if err != nil {
+		handleProvisionError(c, startTime, "provision_build", provisionID, err)
+		return
+	}
+
+	logProvisionChange(c, "provision_build", updated, startTime)
+	recordApplicationMetrics(provisionID, "provision_build", StatusSuccess, startTime)
+	respondProvision(c, http.StatusOK, updated, warnings)
+}
+
+// PromoteCanary - 카나리 빌드 승격 핸들러 (build_number.number=canary.number, canary 삭제)
+// If-Match가 있으면 현재 ETag와 일치하는 경우에만 변경
+// POST /api/v1/admin/provisions/:provision_id/canary/promote
+func PromoteCanary(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	provisionID := c.Param("provision_id")
+	promoted, warnings, err := services.PromoteCanary(adminUser(c), provisionID, c.GetHeader("If-Match"))
+	if err != nil {
+		handleProvisionError(c, startTime, "canary_promote", provisionID, err)
+		return
+	}
+
+	logProvisionChange(c, "canary_promote", promoted, startTime)
+	recordApplicationMetrics(provisionID, "canary_promote", StatusSuccess, startTime)
+	respondProvision(c, http.StatusOK, promoted, warnings)
+}
+
+// AbortCanary - 카나리 빌드 중단 핸들러 (canary 삭제, 모든 요청이 build_number.number 사용)
+// If-Match가 있으면 현재 ETag와 일치하는 경우에만 변경
+// POST /api/v1/admin/provisions/:provision_id/canary/abort
+func AbortCanary(c *gin.Context) {
+	// 요청 시작 시간 기록
+	startTime := time.Now()
+
+	provisionID := c.Param("provision_id")
+	aborted, warnings, err := services.AbortCanary(adminUser(c), provisionID, c.GetHeader("If-Match"))
+	if err != nil {
+		handleProvisionError(c, startTime, "canary_abort", provisionID, err)
+		return
+	}
+
+	logProvisionChange(c, "canary_abort", aborted, startTime)
+	recordApplicationMetrics(provisionID, "canary_abort", StatusSuccess, startTime)
+	respondProvision(c, http.StatusOK, aborted, warnings)
+}
+
+// bindProvisionSpec - 요청 본문을 config.json과 같은 규칙으로 디코딩
+// 경로에 provision_id가 있으면 본문의 provision_id는 생략하거나 같은 값이어야 함 (이름 변경 불가)
+func bindProvisionSpec(c *gin.Context, startTime time.Time, endpoint, provisionID string) (*services.ConfigSpec, bool) {
+	data, err := c.GetRawData()
+	if err != nil {
+		recordApplicationMetrics(provisionID, endpoint, StatusError, startTime)
+		respondError(c, provisionID, endpoint, http.StatusBadRequest, CodeInvalidRequest, "요청 본문을 읽을 수 없습니다: "+err.Error(), nil)
+		return nil, false
+	}
+
+	spec, issues := services.DecodeConfigSpec(data)
+	if len(issues) > 0 {
+		recordApplicationMetrics(provisionID, endpoint, StatusError, startTime)
+		respondError(c, provisionID, endpoint, http.StatusBadRequest, CodeInvalidRequest,
+			"프로비저닝 설정 형식 오류: "+issues[0].Path+": "+issues[0].Message, gin.H{"issues": issues})
+		return nil, false
+	}
+
+	if provisionID != "" {
+		if spec.ProvisionID == "" {
+			spec.ProvisionID = provisionID
+		}
+		if spec.ProvisionID != provisionID {
+			recordApplicationMetrics(provisionID, endpoint, StatusError, startTime)
+			respondError(c, provisionID, endpoint, http.StatusBadRequest, CodeInvalidRequest,
+				"본문의 provision_id("+spec.ProvisionID+")가 경로와 다릅니다", nil)
+			return nil, false
+		}
+	}
+	return spec, true
+}
+
+// respondProvision - ETag 헤더와 함께 프로비저닝 설정 응답
+func respondProvision(c *gin.Context, status int, spec *services.ConfigSpec, warnings services.ConfigIssues) {
+	etag := services.ProvisionETag(spec)
+	c.Header("ETag", etag)
+	c.JSON(status, ProvisionResource{ETag: etag, Spec: spec, Warnings: warnings})
+}
+
+// logProvisionChange - 누가 어떤 프로비저닝을 변경했는지 기록
+func logProvisionChange(c *gin.Context, endpoint string, spec *services.ConfigSpec, startTime time.Time) {
+	logger.Logger.Info("프로비저닝 설정 변경",
+		zap.String(LogFieldEndpoint, endpoint),
+		zap.String(LogFieldProvisionID, spec.ProvisionID),
+		zap.String(LogFieldAdminUser, adminUser(c)),
+		zap.Bool(LogFieldEnabled, spec.Enabled),
+		zap.String("build_number", spec.BuildNumber.Number),
+		zap.String("etag", services.ProvisionETag(spec)),
+		zap.Float64(LogFieldDurationMs, float64(time.Since(startTime).Milliseconds())),
+	)
+}
+
+// handleProvisionError - admin API 오류를 상태 코드/오류 코드로 변환
+func handleProvisionError(c *gin.Context, startTime time.Time, endpoint, provisionID string, err error) {
+	status := http.StatusInternalServerError
+	code := CodeInternal
+	message := "프로비저닝 설정 변경 실패: " + err.Error()
+	var details interface{}
+
+	var invalidErr *services.ConfigInvalidError
+	switch {
+	case errors.As(err, &invalidErr):
+		status, code, message = http.StatusUnprocessableEntity, CodeConfigInvalid, "프로비저닝 설정 검증 실패"
+		details = gin.H{"issues": invalidErr.Issues}
+	case errors.Is(err, services.ErrProvisionNotFound):
+		status, code, message = http.StatusNotFound, CodeProvisionNotFound, "프로비저닝 ID "+provisionID+"를 찾을 수 없음"
+	case errors.Is(err, services.ErrProvisionExists):
+		status, code, message = http.StatusConflict, CodeProvisionExists, "프로비저닝 ID "+provisionID+"가 이미 존재합니다"
+	case errors.Is(err, services.ErrPreconditionFailed):
+		status, code, message = http.StatusPreconditionFailed, CodePreconditionFailed, err.Error()+" (다시 조회한 뒤 변경하세요)"
+	case errors.Is(err, services.ErrCanaryNotActive):
+		status, code, message = http.StatusConflict, CodeCanaryNotActive, "프로비저닝 ID "+provisionID+"에 "+err.Error()
+	case errors.Is(err, services.ErrConfigReadOnly):
+		status, code, message = http.StatusConflict, CodeConfigReadOnly, err.Error()
+	}
+
+	logger.Logger.Warn(message,
+		zap.String(LogFieldEndpoint, endpoint),
+		zap.String(LogFieldProvisionID, provisionID),
+		zap.String(LogFieldAdminUser, adminUser(c)),
+		zap.String("code", string(code)),
+		zap.Error(err),
+	)
+	recordApplicationMetrics(provisionID, endpoint, StatusError, startTime)
+	respondError(c, provisionID, endpoint, status, code, message, details)
+}
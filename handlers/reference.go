@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"service-common/logger"
@@ -34,11 +35,13 @@ const (
 
 // ReferenceRequest - Reference 엔드포인트 요청 파라미터
 type ReferenceRequest struct {
-	ProvisionID string
-	ServiceID   string
-	Category    string
-	UID         string
-	Arguments   string // Optional: 공백으로 구분된 arguments
+	ProvisionID string `json:"provision_id"`
+	ServiceID   string `json:"service_id"`
+	Category    string `json:"category"`
+	UID         string `json:"uid"`
+	Region      string `json:"region,omitempty"`    // Optional: region override 선택용
+	Arguments   string `json:"arguments,omitempty"` // Optional: 공백으로 구분된 arguments
+	NoCache     bool   `json:"-"`                   // nocache=true: MinIO 크기 캐시를 읽지 않고 조회
 }
 
 // GetSparkReference - Reference 엔드포인트 핸들러
@@ -81,12 +84,12 @@ func GetSparkReference(c *gin.Context) {
 
 	// 4. enabled 확인 및 처리
 	if !services.IsProvisionEnabled(provisionConfig) {
-		handleReferenceDisabled(c, startTime, &req, provisionConfig, yamlTemplate)
+		handleReferenceDisabled(c, startTime, &req, provisionConfig, yamlTemplate, config.Revision)
 		return
 	}
 
 	// 5. 활성화 모드 처리
-	handleReferenceEnabled(c, startTime, &req, provisionConfig, yamlTemplate)
+	handleReferenceEnabled(c, startTime, &req, provisionConfig, yamlTemplate, config.Revision)
 }
 
 // parseReferenceRequest extracts request parameters from query string
@@ -96,7 +99,9 @@ func parseReferenceRequest(c *gin.Context) ReferenceRequest {
 		ServiceID:   c.Query("service_id"),
 		Category:    c.Query("category"),
 		UID:         c.Query("uid"),
+		Region:      c.Query("region"),
 		Arguments:   c.Query("arguments"),
+		NoCache:     wantsNoCache(c),
 	}
 }
 
@@ -105,11 +110,17 @@ func validateReferenceRequest(req *ReferenceRequest) error {
 	if req.ProvisionID == "" || req.ServiceID == "" || req.Category == "" || req.UID == "" {
 		return fmt.Errorf("필수 파라미터가 누락되었습니다. provision_id, service_id, category, uid가 모두 필요합니다")
 	}
-	// 서비스 아이디 정규화: _를 -로 변환
-	req.ServiceID = strings.ReplaceAll(req.ServiceID, "_", "-")
+	req.ServiceID = normalizeServiceID(req.ServiceID)
 	return nil
 }
 
+// normalizeServiceID - 서비스 아이디 정규화: _를 -로 변환
+// 폴더 모드의 트레일링 슬래시와 대소문자는 MinIO 경로에 필요하므로 유지
+// (SparkApplication 이름에는 services.SparkApplicationName이 슬래시 제거/소문자로 사용)
+func normalizeServiceID(serviceID string) string {
+	return strings.ReplaceAll(serviceID, "_", "-")
+}
+
 // logReferenceRequestReceived logs incoming reference request
 func logReferenceRequestReceived(req *ReferenceRequest) {
 	logger.Logger.Info("Reference 요청 수신",
@@ -130,9 +141,7 @@ func handleReferenceValidationError(c *gin.Context, startTime time.Time, req *Re
 	)
 	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "reference", StatusError).Inc()
 	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "reference").Observe(time.Since(startTime).Seconds())
-	c.JSON(400, gin.H{
-		"error": message,
-	})
+	respondError(c, req.ProvisionID, "reference", 400, CodeInvalidRequest, message, nil)
 }
 
 // handleReferenceTemplateError handles template loading errors
@@ -146,9 +155,7 @@ func handleReferenceTemplateError(c *gin.Context, startTime time.Time, req *Refe
 	)
 	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "reference", StatusError).Inc()
 	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "reference").Observe(time.Since(startTime).Seconds())
-	c.JSON(404, gin.H{
-		"error": fmt.Sprintf("템플릿 로드 실패: %v", err),
-	})
+	respondError(c, req.ProvisionID, "reference", 404, CodeTemplateMissing, fmt.Sprintf("템플릿 로드 실패: %v", err), nil)
 }
 
 // handleReferenceConfigError handles config loading errors
@@ -162,9 +169,7 @@ func handleReferenceConfigError(c *gin.Context, startTime time.Time, req *Refere
 	)
 	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "reference", StatusError).Inc()
 	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "reference").Observe(time.Since(startTime).Seconds())
-	c.JSON(500, gin.H{
-		"error": fmt.Sprintf("설정 로드 실패: %v", err),
-	})
+	respondError(c, req.ProvisionID, "reference", 500, CodeConfigUnavailable, fmt.Sprintf("설정 로드 실패: %v", err), nil)
 }
 
 // handleReferenceProvisionError handles provision config errors
@@ -178,19 +183,31 @@ func handleReferenceProvisionError(c *gin.Context, startTime time.Time, req *Ref
 	)
 	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "reference", StatusError).Inc()
 	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "reference").Observe(time.Since(startTime).Seconds())
-	c.JSON(404, gin.H{
-		"error": fmt.Sprintf("프로비저닝 설정 찾기 실패: %v", err),
-	})
+	respondError(c, req.ProvisionID, "reference", 404, CodeProvisionNotFound, fmt.Sprintf("프로비저닝 설정 찾기 실패: %v", err), nil)
 }
 
 // handleReferenceDisabled handles disabled provision mode for reference
-func handleReferenceDisabled(c *gin.Context, startTime time.Time, req *ReferenceRequest, provisionConfig *services.ConfigSpec, yamlTemplate string) {
+func handleReferenceDisabled(c *gin.Context, startTime time.Time, req *ReferenceRequest, provisionConfig *services.ConfigSpec, yamlTemplate string, revision int64) {
+	yamlOutput, trace := renderDisabledYAML("reference", req, provisionConfig, yamlTemplate, revision)
+
+	logReferenceYAMLComplete(req, yamlOutput, startTime, false)
+	completeReference(c, startTime, req, yamlOutput, trace)
+}
+
+// renderDisabledYAML - 비활성화 모드 YAML 렌더링 (리소스 계산 없이 빌드 번호/arguments/라벨만 적용)
+// endpoint는 렌더링 로그의 endpoint 필드 (reference, reference_batch, create)
+func renderDisabledYAML(endpoint string, req *ReferenceRequest, provisionConfig *services.ConfigSpec, yamlTemplate string, revision int64) (string, *DecisionTrace) {
+	// category/region override 적용 (build_number, image, namespace)
+	resolved := resolveProvision(endpoint, req, provisionConfig)
+	provisionConfig = resolved.Spec
+	build, image := selectBuild(endpoint, req, provisionConfig)
+
 	logger.Logger.Info("프로비저닝 비활성화 모드",
-		zap.String(LogFieldEndpoint, "reference"),
+		zap.String(LogFieldEndpoint, endpoint),
 		zap.String(LogFieldProvisionID, req.ProvisionID),
 		zap.String(LogFieldServiceID, req.ServiceID),
 		zap.String(LogFieldCategory, req.Category),
-		zap.String(LogFieldEnabled, provisionConfig.Enabled),
+		zap.Bool(LogFieldEnabled, provisionConfig.Enabled),
 		zap.String(LogFieldReason, "disabled"),
 	)
 
@@ -198,30 +215,57 @@ func handleReferenceDisabled(c *gin.Context, startTime time.Time, req *Reference
 	metrics.ProvisionMode.WithLabelValues(req.ProvisionID, "false").Inc()
 	metrics.ResourceCalculationSkipped.WithLabelValues(req.ProvisionID, "disabled").Inc()
 
-	// build_number 적용
-	yamlTemplate = services.ApplyBuildNumberToYAML(yamlTemplate, provisionConfig.BuildNumber.Number)
+	// build_number 적용 (카나리 대상이면 canary.number), image 설정의 저장소/형식 사용, digest가 있으면 image 고정
+	yamlTemplate = services.ApplyBuildToYAML(yamlTemplate, provisionConfig.Image, image)
 
 	// Arguments 적용 (사용자 제공 시)
 	yamlTemplate = services.ApplyArgumentsToYAML(yamlTemplate, req.Arguments)
 
-	// 서비스 ID 라벨 적용
-	yamlOutput := services.ApplyServiceIDLabelsToYAML(yamlTemplate, req.ServiceID)
+	// 서비스 ID 라벨 적용 (활성화 모드와 같은 service_id-category-uid 이름, lifecycle API 조회와 동일)
+	yamlOutput := services.ApplyServiceIDLabelsWithUIDToYAML(yamlTemplate, req.ServiceID, req.Category, req.UID)
+	yamlOutput = services.ApplyMetadataLabelsToYAML(yamlOutput, applicationLabels(req, build))
+
+	trace := &DecisionTrace{
+		ProvisionID:  req.ProvisionID,
+		Enabled:      false,
+		BuildNumber:  build.Number,
+		BuildVersion: image.Version,
+		BuildTag:     image.Tag,
+		BuildLabel:   image.Label,
+		BuildDigest:  build.Digest,
+		BuildTrack:   build.Track,
+		BuildReason:  build.Reason,
+	}
+	return finishRender(endpoint, yamlOutput, trace, req, resolved, revision), trace
+}
 
-	logReferenceYAMLComplete(req, yamlOutput, startTime, false)
-	recordReferenceSuccessMetrics(req.ProvisionID, startTime)
+// handleReferenceEnabled handles enabled provision mode for reference
+func handleReferenceEnabled(c *gin.Context, startTime time.Time, req *ReferenceRequest, provisionConfig *services.ConfigSpec, yamlTemplate string, revision int64) {
+	yamlOutput, trace, err := renderEnabledYAML("reference", req, provisionConfig, yamlTemplate, revision)
+	if err != nil {
+		handleReferenceMinIOError(c, startTime, req, err)
+		return
+	}
 
-	// 클라이언트에게 YAML 응답
-	sendYAMLResponse(c, yamlOutput)
+	logReferenceYAMLComplete(req, yamlOutput, startTime, true)
+	completeReference(c, startTime, req, yamlOutput, trace)
 }
 
-// handleReferenceEnabled handles enabled provision mode for reference
-func handleReferenceEnabled(c *gin.Context, startTime time.Time, req *ReferenceRequest, provisionConfig *services.ConfigSpec, yamlTemplate string) {
+// renderEnabledYAML - 활성화 모드 YAML 렌더링 (MinIO 크기 기반 티어 계산 후 큐/executor/빌드 번호/라벨 적용)
+// MinIO 서버에 연결할 수 없으면 잘못된 티어로 제출하지 않도록 오류 반환 (그 외 조회 실패는 기본 티어 + 경고)
+// endpoint는 렌더링 로그의 endpoint 필드 (reference, reference_batch, create)
+func renderEnabledYAML(endpoint string, req *ReferenceRequest, provisionConfig *services.ConfigSpec, yamlTemplate string, revision int64) (string, *DecisionTrace, error) {
+	// category/region override 적용 (tiers, gang_scheduling, build_number, image, namespace)
+	resolved := resolveProvision(endpoint, req, provisionConfig)
+	provisionConfig = resolved.Spec
+	build, image := selectBuild(endpoint, req, provisionConfig)
+
 	logger.Logger.Info("프로비저닝 활성화 모드",
-		zap.String(LogFieldEndpoint, "reference"),
+		zap.String(LogFieldEndpoint, endpoint),
 		zap.String(LogFieldProvisionID, req.ProvisionID),
 		zap.String(LogFieldServiceID, req.ServiceID),
 		zap.String(LogFieldCategory, req.Category),
-		zap.String(LogFieldEnabled, provisionConfig.Enabled),
+		zap.Bool(LogFieldEnabled, provisionConfig.Enabled),
 	)
 
 	// 메트릭 기록
@@ -231,31 +275,60 @@ func handleReferenceEnabled(c *gin.Context, startTime time.Time, req *ReferenceR
 	// MinIO 경로: config의 resource_calculation.minio 값에서 <<service_id>>를 service_id로 치환
 	// 3단계 티어 기반 큐 및 executor 계산 (small/medium/large)
 	tierResult, err := services.CalculateQueueWithTiers(
+		provisionConfig.ResourceCalculation.MinioServer,
 		provisionConfig.ResourceCalculation.Minio,
 		req.ServiceID,
 		provisionConfig.ResourceCalculation.Tiers,
+		req.NoCache,
 	)
 
 	queue := tierResult.Queue
-	executorCount := tierResult.ExecutorInt
+	executorCount := tierResult.Executor
 	fileSize := tierResult.TotalSize
 	metadata := tierResult.Metadata
 	count := tierResult.ObjectCount
 
-	logResourceCalculationReference(req, provisionConfig, queue, fileSize, executorCount)
+	logResourceCalculationReference(endpoint, req, provisionConfig, queue, fileSize, executorCount)
 
 	// 5. MinIO 메타데이터 로그 출력
 	if metadata != nil {
-		logMinIOMetadataReference(req, metadata)
+		logMinIOMetadataReference(endpoint, req, metadata)
 	}
 
+	trace := &DecisionTrace{
+		ProvisionID:        req.ProvisionID,
+		Enabled:            true,
+		MinioPath:          tierResult.MinioPath,
+		MinioURL:           tierResult.MinioURL,
+		SizeCache:          tierResult.SizeCache,
+		TotalSize:          fileSize,
+		TotalSizeFormatted: services.FormatBytes(fileSize),
+		ObjectCount:        count,
+		Tiers:              tierResult.Evaluations,
+		SelectedTier:       tierResult.TierName,
+		Queue:              queue,
+		ExecutorCount:      executorCount,
+		BuildNumber:        build.Number,
+		BuildVersion:       image.Version,
+		BuildTag:           image.Tag,
+		BuildLabel:         image.Label,
+		BuildDigest:        build.Digest,
+		BuildTrack:         build.Track,
+		BuildReason:        build.Reason,
+	}
+
+	if services.IsMinIOUnavailable(err) {
+		return "", trace, err
+	}
 	if err != nil {
-		// MinIO 오류는 경고로 처리하고 계속 진행 (기본값 사용)
+		// 환경 변수 미설정/객체 없음 등은 경고로 처리하고 계속 진행 (기본값 사용)
 		logger.Logger.Warn("MinIO 리소스 계산 경고",
-			zap.String(LogFieldEndpoint, "reference"),
+			zap.String(LogFieldEndpoint, endpoint),
 			zap.String(LogFieldProvisionID, req.ProvisionID),
+			zap.String("minio_url", tierResult.MinioURL),
 			zap.Error(err),
 		)
+		trace.Warning = err.Error()
 	}
 
 	metrics.QueueSelection.WithLabelValues(req.ProvisionID, queue).Inc()
@@ -269,7 +342,7 @@ func handleReferenceEnabled(c *gin.Context, startTime time.Time, req *ReferenceR
 	yamlTemplate = updateQueueInYAML(yamlTemplate, queue)
 
 	// Gang Scheduling 설정 적용 (티어에서 결정된 executor 개수 사용)
-	logGangSchedulingConfigReference(req, provisionConfig, executorCount)
+	logGangSchedulingConfigReference(endpoint, req, provisionConfig, executorCount)
 	recordGangSchedulingMetrics(req.ProvisionID, provisionConfig, executorCount)
 
 	// task-groups의 executor minMember 업데이트
@@ -278,8 +351,8 @@ func handleReferenceEnabled(c *gin.Context, startTime time.Time, req *ReferenceR
 	// Template 처리 로직 2: 티어에서 결정된 executor 개수를 spec.executor.instances에 대입
 	yamlTemplate = services.UpdateExecutorInstances(yamlTemplate, executorCount)
 
-	// Template 처리 로직 3: config.json의 build_number.number를 BUILD_NUMBER에 대입
-	yamlTemplate = services.ApplyBuildNumberToYAML(yamlTemplate, provisionConfig.BuildNumber.Number)
+	// Template 처리 로직 3: config.json의 build_number.number(카나리 대상이면 canary.number)로 만든 태그/라벨/버전을 BUILD_NUMBER에 대입
+	yamlTemplate = services.ApplyBuildToYAML(yamlTemplate, provisionConfig.Image, image)
 
 	// Arguments 적용 (사용자 제공 시)
 	yamlTemplate = services.ApplyArgumentsToYAML(yamlTemplate, req.Arguments)
@@ -287,32 +360,108 @@ func handleReferenceEnabled(c *gin.Context, startTime time.Time, req *ReferenceR
 	// 서비스 ID 라벨 적용 (UID 포함)
 	yamlOutput := services.ApplyServiceIDLabelsWithUIDToYAML(yamlTemplate, req.ServiceID, req.Category, req.UID)
 
-	logReferenceYAMLComplete(req, yamlOutput, startTime, true)
-	recordReferenceSuccessMetrics(req.ProvisionID, startTime)
+	// lifecycle API 목록 필터용 provision-id/category/build-track 라벨 적용
+	yamlOutput = services.ApplyMetadataLabelsToYAML(yamlOutput, applicationLabels(req, build))
+	return finishRender(endpoint, yamlOutput, trace, req, resolved, revision), trace, nil
+}
 
-	// 클라이언트에게 YAML 응답
-	sendYAMLResponse(c, yamlOutput)
+// resolveProvision - 요청의 category/region에 맞는 overrides 적용 (적용된 override가 있으면 로그)
+func resolveProvision(endpoint string, req *ReferenceRequest, provisionConfig *services.ConfigSpec) *services.ResolvedProvision {
+	resolved := services.ResolveProvision(provisionConfig, req.Category, req.Region)
+	if len(resolved.Applied) > 0 {
+		logger.Logger.Info("프로비저닝 override 적용",
+			zap.String(LogFieldEndpoint, endpoint),
+			zap.String(LogFieldProvisionID, req.ProvisionID),
+			zap.String(LogFieldCategory, req.Category),
+			zap.String(LogFieldRegion, req.Region),
+			zap.Any("overrides", resolved.Applied),
+		)
+	}
+	return resolved
 }
 
-// handleReferenceCalculationError handles resource calculation errors
-func handleReferenceCalculationError(c *gin.Context, startTime time.Time, req *ReferenceRequest, err error) {
-	logger.Logger.Error("리소스 계산 실패",
-		zap.String(LogFieldEndpoint, "reference"),
-		zap.String(LogFieldProvisionID, req.ProvisionID),
-		zap.String(LogFieldServiceID, req.ServiceID),
-		zap.String(LogFieldCategory, req.Category),
-		zap.Error(err),
-	)
-	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "reference", StatusError).Inc()
-	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "reference").Observe(time.Since(startTime).Seconds())
-	c.JSON(500, gin.H{
-		"error": fmt.Sprintf("리소스 계산 실패: %v", err),
-	})
+// finishRender - override namespace와 설정 revision annotation을 YAML에 적용하고 trace에 기록
+func finishRender(endpoint, yamlOutput string, trace *DecisionTrace, req *ReferenceRequest, resolved *services.ResolvedProvision, revision int64) string {
+	yamlOutput = services.ApplyNamespaceToYAML(yamlOutput, resolved.Namespace)
+	yamlOutput = services.ApplyConfigRevisionToYAML(yamlOutput, revision)
+
+	trace.Region = req.Region
+	trace.Namespace = resolved.Namespace
+	trace.Overrides = resolved.Applied
+	trace.ConfigRevision = revision
+	trace.Images = checkImages(endpoint, req, yamlOutput)
+	return yamlOutput
+}
+
+// checkImages - 렌더링된 CR의 image가 레지스트리에 있는지 확인 (IMAGE_CHECK_REGISTRIES가 없으면 생략)
+// reference는 경고로만 기록하고, create는 missing이면 제출하지 않음
+func checkImages(endpoint string, req *ReferenceRequest, yamlOutput string) []services.ImageCheck {
+	checker := services.ActiveImageChecker()
+	if checker == nil {
+		return nil
+	}
+
+	results := checker.CheckYAML(context.Background(), yamlOutput)
+	for _, result := range results {
+		if result.Status == services.ImageFound {
+			continue
+		}
+		logger.Logger.Warn("이미지 확인 경고",
+			zap.String(LogFieldEndpoint, endpoint),
+			zap.String(LogFieldProvisionID, req.ProvisionID),
+			zap.String(LogFieldServiceID, req.ServiceID),
+			zap.String("image", result.Image),
+			zap.String("status", result.Status),
+			zap.String(LogFieldReason, result.Message),
+		)
+	}
+	return results
+}
+
+// missingImages - 레지스트리에 없는 것으로 확인된 이미지 (확인 실패는 제외)
+func missingImages(results []services.ImageCheck) []services.ImageCheck {
+	var missing []services.ImageCheck
+	for _, result := range results {
+		if result.Status == services.ImageMissing {
+			missing = append(missing, result)
+		}
+	}
+	return missing
+}
+
+// selectBuild - 카나리 설정에 따라 사용할 빌드를 선택하고 image 설정 형식으로 버전/태그/라벨 생성
+// 빌드별 메트릭을 기록하고, 카나리 설정이 있으면 로그
+func selectBuild(endpoint string, req *ReferenceRequest, provisionConfig *services.ConfigSpec) (services.BuildSelection, services.BuildImage) {
+	build := services.SelectBuild(provisionConfig.BuildNumber, req.ServiceID, req.Category)
+	image := provisionConfig.Image.Build(build.Number, build.Digest)
+	if provisionConfig.BuildNumber.Canary != nil {
+		logger.Logger.Info("빌드 선택",
+			zap.String(LogFieldEndpoint, endpoint),
+			zap.String(LogFieldProvisionID, req.ProvisionID),
+			zap.String(LogFieldServiceID, req.ServiceID),
+			zap.String(LogFieldCategory, req.Category),
+			zap.String("build_number", build.Number),
+			zap.String("build_digest", build.Digest),
+			zap.String("build_track", build.Track),
+			zap.String(LogFieldReason, build.Reason),
+		)
+	}
+	metrics.BuildSelection.WithLabelValues(req.ProvisionID, image.Version, build.Track).Inc()
+	return build, image
 }
 
-// handleReferenceExecutorError handles executor config errors
-func handleReferenceExecutorError(c *gin.Context, startTime time.Time, req *ReferenceRequest, err error) {
-	logger.Logger.Error("executor 설정 변환 실패",
+// applicationLabels - SparkApplication metadata에 추가할 provision-id/category/build-track 라벨
+func applicationLabels(req *ReferenceRequest, build services.BuildSelection) map[string]string {
+	return map[string]string{
+		services.LabelProvisionID: req.ProvisionID,
+		services.LabelCategory:    req.Category,
+		services.LabelBuildTrack:  build.Track,
+	}
+}
+
+// handleReferenceMinIOError handles MinIO connection errors during resource calculation
+func handleReferenceMinIOError(c *gin.Context, startTime time.Time, req *ReferenceRequest, err error) {
+	logger.Logger.Error("MinIO 크기 조회 실패",
 		zap.String(LogFieldEndpoint, "reference"),
 		zap.String(LogFieldProvisionID, req.ProvisionID),
 		zap.String(LogFieldServiceID, req.ServiceID),
@@ -321,15 +470,13 @@ func handleReferenceExecutorError(c *gin.Context, startTime time.Time, req *Refe
 	)
 	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "reference", StatusError).Inc()
 	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "reference").Observe(time.Since(startTime).Seconds())
-	c.JSON(500, gin.H{
-		"error": fmt.Sprintf("executor 설정 변환 실패: %v", err),
-	})
+	respondError(c, req.ProvisionID, "reference", 502, CodeMinIOUnavailable, fmt.Sprintf("MinIO 크기 조회 실패: %v", err), nil)
 }
 
 // logResourceCalculationReference logs resource calculation for reference
-func logResourceCalculationReference(req *ReferenceRequest, config *services.ConfigSpec, queue string, fileSize int64, executorCount int) {
+func logResourceCalculationReference(endpoint string, req *ReferenceRequest, config *services.ConfigSpec, queue string, fileSize int64, executorCount int) {
 	logger.Logger.Info("리소스 계산 완료",
-		zap.String(LogFieldEndpoint, "reference"),
+		zap.String(LogFieldEndpoint, endpoint),
 		zap.String(LogFieldProvisionID, req.ProvisionID),
 		zap.String(LogFieldServiceID, req.ServiceID),
 		zap.String(LogFieldCategory, req.Category),
@@ -341,9 +488,9 @@ func logResourceCalculationReference(req *ReferenceRequest, config *services.Con
 }
 
 // logGangSchedulingConfigReference logs gang scheduling config for reference
-func logGangSchedulingConfigReference(req *ReferenceRequest, config *services.ConfigSpec, executorMinMember int) {
+func logGangSchedulingConfigReference(endpoint string, req *ReferenceRequest, config *services.ConfigSpec, executorMinMember int) {
 	logger.Logger.Info("Gang Scheduling 구성",
-		zap.String(LogFieldEndpoint, "reference"),
+		zap.String(LogFieldEndpoint, endpoint),
 		zap.String(LogFieldProvisionID, req.ProvisionID),
 		zap.String(LogFieldServiceID, req.ServiceID),
 		zap.String(LogFieldCategory, req.Category),
@@ -394,6 +541,102 @@ func recordReferenceSuccessMetrics(provisionID string, startTime time.Time) {
 	metrics.RequestDuration.WithLabelValues(provisionID, "reference").Observe(time.Since(startTime).Seconds())
 }
 
+// completeReference - validate=true 이면 dry-run 검증 결과를 포함하여 응답, 아니면 YAML/JSON 응답
+func completeReference(c *gin.Context, startTime time.Time, req *ReferenceRequest, yamlOutput string, trace *DecisionTrace) {
+	if !wantsValidation(c) {
+		recordReferenceSuccessMetrics(req.ProvisionID, startTime)
+		// 클라이언트에게 YAML (또는 format=json 시 YAML + decision trace) 응답
+		sendReferenceResponse(c, yamlOutput, trace)
+		return
+	}
+
+	validation, err := validateRenderedYAML("reference", req, yamlOutput)
+	if err != nil {
+		handleReferenceDryRunError(c, startTime, req, err)
+		return
+	}
+
+	recordReferenceSuccessMetrics(req.ProvisionID, startTime)
+
+	// 검증 결과는 YAML 옆에 함께 반환해야 하므로 항상 JSON 응답
+	c.JSON(200, ReferenceResponse{
+		YAML:       yamlOutput,
+		Trace:      trace,
+		Validation: validation,
+	})
+}
+
+// wantsValidation - validate=true 쿼리 확인
+func wantsValidation(c *gin.Context) bool {
+	return c.Query("validate") == "true"
+}
+
+// wantsNoCache - nocache=true 쿼리 확인 (MinIO 크기 캐시를 읽지 않고 새로 조회)
+func wantsNoCache(c *gin.Context) bool {
+	return c.Query("nocache") == "true"
+}
+
+// validateRenderedYAML - 렌더링된 YAML을 Kubernetes API 서버에 dry-run으로 검증하고 로그/메트릭 기록
+func validateRenderedYAML(endpoint string, req *ReferenceRequest, yamlOutput string) (*services.ValidationResult, error) {
+	validation, err := services.ValidateSparkApplicationYAML(yamlOutput)
+	if err != nil {
+		metrics.DryRunValidation.WithLabelValues(req.ProvisionID, endpoint, StatusError).Inc()
+		return nil, err
+	}
+
+	result := "valid"
+	if !validation.Valid {
+		result = "invalid"
+	}
+	metrics.DryRunValidation.WithLabelValues(req.ProvisionID, endpoint, result).Inc()
+
+	logger.Logger.Info("Dry-run 검증 완료",
+		zap.String(LogFieldEndpoint, endpoint),
+		zap.String(LogFieldProvisionID, req.ProvisionID),
+		zap.String(LogFieldServiceID, req.ServiceID),
+		zap.String(LogFieldCategory, req.Category),
+		zap.Bool("valid", validation.Valid),
+		zap.Strings("errors", validation.Errors),
+	)
+	return validation, nil
+}
+
+// handleReferenceDryRunError handles dry-run validation errors (API 서버 연결 실패 등)
+func handleReferenceDryRunError(c *gin.Context, startTime time.Time, req *ReferenceRequest, err error) {
+	logger.Logger.Error("Dry-run 검증 수행 실패",
+		zap.String(LogFieldEndpoint, "reference"),
+		zap.String(LogFieldProvisionID, req.ProvisionID),
+		zap.String(LogFieldServiceID, req.ServiceID),
+		zap.String(LogFieldCategory, req.Category),
+		zap.Error(err),
+	)
+	metrics.RequestsTotal.WithLabelValues(req.ProvisionID, "reference", StatusError).Inc()
+	metrics.RequestDuration.WithLabelValues(req.ProvisionID, "reference").Observe(time.Since(startTime).Seconds())
+	status, code := kubernetesErrorStatus(err)
+	respondError(c, req.ProvisionID, "reference", status, code, fmt.Sprintf("Dry-run 검증 수행 실패: %v", err), nil)
+}
+
+// sendReferenceResponse sends YAML, or YAML with decision trace when JSON is requested
+func sendReferenceResponse(c *gin.Context, yamlOutput string, trace *DecisionTrace) {
+	if wantsJSONResponse(c) {
+		c.JSON(200, ReferenceResponse{
+			YAML:  yamlOutput,
+			Trace: trace,
+		})
+		return
+	}
+	sendYAMLResponse(c, yamlOutput)
+}
+
+// wantsJSONResponse - format=json 쿼리 또는 Accept: application/json 헤더 확인
+// format 쿼리가 지정된 경우 Accept 헤더보다 우선
+func wantsJSONResponse(c *gin.Context) bool {
+	if format := c.Query("format"); format != "" {
+		return format == "json"
+	}
+	return strings.Contains(c.GetHeader("Accept"), "application/json")
+}
+
 // sendYAMLResponse sends YAML response to client
 func sendYAMLResponse(c *gin.Context, yamlOutput string) {
 	c.Header("Content-Type", "application/x-yaml")
@@ -401,10 +644,10 @@ func sendYAMLResponse(c *gin.Context, yamlOutput string) {
 }
 
 // logMinIOMetadataReference - MinIO 파일 메타데이터 로그 (5번째 로그)
-func logMinIOMetadataReference(req *ReferenceRequest, metadata *services.MinIOMetadata) {
+func logMinIOMetadataReference(endpoint string, req *ReferenceRequest, metadata *services.MinIOMetadata) {
 	metadataLog := map[string]interface{}{
 		"log_type":       "minio_metadata",
-		"endpoint":       "reference",
+		"endpoint":       endpoint,
 		"provision_id":   req.ProvisionID,
 		"service_id":     req.ServiceID,
 		"minio_path":     metadata.Path,
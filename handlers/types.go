@@ -1,5 +1,11 @@
 package handlers
 
+import (
+	"encoding/json"
+
+	"service-common/services"
+)
+
 // CreateRequest - Create 엔드포인트 요청 구조체
 type CreateRequest struct {
 	ProvisionID string `json:"provision_id" binding:"required"`
@@ -7,5 +13,125 @@ type CreateRequest struct {
 	Category    string `json:"category" binding:"required"`
 	Region      string `json:"region" binding:"required"`
 	UID         string `json:"uid" binding:"required"`
-	Arguments   string `json:"arguments" binding:"optional"` // Optional: 공백으로 구분된 arguments (예: "111 222 333")
+	Arguments   string `json:"arguments"`   // Optional: 공백으로 구분된 arguments (예: "111 222 333")
+	OnConflict  string `json:"on_conflict"` // Optional: reject(기본값), replace-if-terminal, force
+}
+
+// toReferenceRequest - Create 요청을 reference 렌더링 파이프라인 요청으로 변환
+func (r *CreateRequest) toReferenceRequest() ReferenceRequest {
+	return ReferenceRequest{
+		ProvisionID: r.ProvisionID,
+		ServiceID:   r.ServiceID,
+		Category:    r.Category,
+		UID:         r.UID,
+		Region:      r.Region,
+		Arguments:   r.Arguments,
+	}
+}
+
+// BuildUpdateRequest - 빌드 번호 설정 요청 (CI webhook)
+type BuildUpdateRequest struct {
+	Number    string              `json:"number" binding:"required"` // minor 버전 (예: "14" → 4.14.1)
+	Digest    string              `json:"digest"`                    // Optional: 이미지 digest (sha256:...), 지정 시 image를 digest로 고정
+	UpdatedBy string              `json:"updated_by"`                // Optional: build_number.updated_by (CI 작업 등, 비우면 토큰 이름)
+	Canary    *BuildCanaryRequest `json:"canary"`                    // Optional: 지정하면 카나리 빌드로 설정
+}
+
+// BuildCanaryRequest - 카나리 대상 (percent 또는 category 허용 목록)
+type BuildCanaryRequest struct {
+	Percent    int      `json:"percent"`
+	Categories []string `json:"categories"`
+}
+
+// DecisionTrace - reference 렌더링 결정 과정 (format=json 응답에 포함)
+type DecisionTrace struct {
+	ProvisionID        string                     `json:"provision_id"`
+	Enabled            bool                       `json:"enabled"`
+	Region             string                     `json:"region,omitempty"`
+	MinioPath          string                     `json:"minio_path,omitempty"`
+	MinioURL           string                     `json:"minio_url,omitempty"`  // 조회한 MinIO 서버 (minio_server 또는 MINIO_ENDPOINT)
+	SizeCache          string                     `json:"size_cache,omitempty"` // 크기 캐시 결과 (hit, miss, revalidated, bypass)
+	TotalSize          int64                      `json:"total_size_bytes"`
+	TotalSizeFormatted string                     `json:"total_size_formatted,omitempty"`
+	ObjectCount        int                        `json:"object_count"`
+	Tiers              []services.TierEvaluation  `json:"tiers,omitempty"`
+	SelectedTier       string                     `json:"selected_tier,omitempty"`
+	Queue              string                     `json:"queue,omitempty"`
+	ExecutorCount      int                        `json:"executor_count,omitempty"`
+	Warning            string                     `json:"warning,omitempty"` // MinIO 조회 실패로 기본 티어를 사용한 경우
+	BuildNumber        string                     `json:"build_number"`
+	BuildVersion       string                     `json:"build_version"`             // version_format 결과 (기본 4.{number}.1)
+	BuildTag           string                     `json:"build_tag"`                 // image 태그 (tag_format)
+	BuildLabel         string                     `json:"build_label"`               // build-number 라벨 값 (label_format)
+	BuildDigest        string                     `json:"build_digest,omitempty"`    // image에 고정한 digest
+	BuildTrack         string                     `json:"build_track"`               // stable, canary
+	BuildReason        string                     `json:"build_reason,omitempty"`    // 카나리 설정이 있을 때 빌드 선택 이유
+	ConfigRevision     int64                      `json:"config_revision,omitempty"` // 렌더링에 사용한 설정 revision (hynix.io/config-revision)
+	Overrides          []services.AppliedOverride `json:"overrides,omitempty"`       // 적용된 category/region override (적용 순서, 뒤가 우선)
+	Namespace          string                     `json:"namespace,omitempty"`       // override된 namespace
+	Images             []services.ImageCheck      `json:"images,omitempty"`          // 레지스트리 이미지 확인 결과 (IMAGE_CHECK_REGISTRIES)
+}
+
+// ReferenceResponse - reference 엔드포인트 JSON 응답 (format=json 또는 validate=true)
+type ReferenceResponse struct {
+	YAML       string                     `json:"yaml"`
+	Trace      *DecisionTrace             `json:"trace"`
+	Validation *services.ValidationResult `json:"validation,omitempty"` // validate=true 인 경우에만 포함
+}
+
+// BatchReferenceRequest - Batch reference 엔드포인트 요청 구조체
+type BatchReferenceRequest struct {
+	Items       []ReferenceRequest `json:"items" binding:"required"`
+	Concurrency int                `json:"concurrency"` // Optional: 동시 처리 개수 (기본값: BATCH_CONCURRENCY)
+}
+
+// BatchReferenceResult - Batch 항목별 렌더링 결과 (성공 시 yaml/trace, 실패 시 error)
+type BatchReferenceResult struct {
+	Index       int            `json:"index"`
+	ProvisionID string         `json:"provision_id"`
+	ServiceID   string         `json:"service_id"`
+	Category    string         `json:"category"`
+	UID         string         `json:"uid"`
+	YAML        string         `json:"yaml,omitempty"`
+	Trace       *DecisionTrace `json:"trace,omitempty"`
+	Error       *APIError      `json:"error,omitempty"`
+}
+
+// BatchReferenceResponse - Batch reference 엔드포인트 응답
+type BatchReferenceResponse struct {
+	Results   []BatchReferenceResult `json:"results"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+}
+
+// ProvisionResource - admin API 프로비저닝 응답 (etag는 ETag 헤더와 같은 값)
+type ProvisionResource struct {
+	ETag     string                `json:"etag"`
+	Spec     *services.ConfigSpec  `json:"spec"`
+	Warnings services.ConfigIssues `json:"warnings,omitempty"` // 변경 요청에서 발견된 warning
+}
+
+// ProvisionListResponse - admin API 프로비저닝 목록 응답
+type ProvisionListResponse struct {
+	Source string              `json:"source"` // 설정 출처 (file, crd)
+	Items  []ProvisionResource `json:"items"`
+}
+
+// ConfigRevisionListResponse - admin API 설정 revision 목록 응답 (최신순)
+type ConfigRevisionListResponse struct {
+	Current int64                     `json:"current"` // 현재 적용 중인 revision (기록 실패 시 0)
+	Items   []services.ConfigRevision `json:"items"`
+}
+
+// ConfigRevisionResponse - admin API 설정 revision 조회 응답 (적용된 설정 원문 포함)
+type ConfigRevisionResponse struct {
+	services.ConfigRevision
+	Config json.RawMessage `json:"config"`
+}
+
+// ConfigRollbackResponse - admin API 롤백 응답
+type ConfigRollbackResponse struct {
+	Revision services.ConfigRevision `json:"revision"` // 롤백 결과로 적용된 revision
+	From     int64                   `json:"from"`     // 되돌린 대상 revision
+	Warnings services.ConfigIssues   `json:"warnings,omitempty"`
 }
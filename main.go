@@ -12,22 +12,49 @@
 // Usage:
 //
 //	./hynix
+//	./hynix -config config/config.json -config config/config.prod.json -templates template
+//	./hynix config validate -file config/config.json
+//	./hynix config migrate -file config/config.json
+//	./hynix image check localhost:5000/spark:4.13.1
 //
 // Environment:
 //   PORT: Server port (default: 8080)
+//   CONFIG_SOURCE: provisioning config source, file or crd (default: file)
+//   CONFIG_FILES: comma-separated config files deep-merged in order, ${ENV} expanded (default: ./config/config.json)
+//   TEMPLATE_DIR: SparkApplication template directory (default: ./template)
+//   CONFIG_RELOAD_INTERVAL: config.json checksum check interval (default: 30s)
+//   CONFIG_NAMESPACE: namespace watched for ProvisionConfig resources (default: all)
+//   CONFIG_HISTORY_DIR: directory for applied config revisions (default: ./config/history)
+//   CONFIG_HISTORY_LIMIT: number of config revisions to keep (default: 100)
+//   ADMIN_TOKENS: provision admin API tokens, "name:token,..." (default: admin API disabled)
+//                 with CONFIG_SOURCE=file, the config directory and CONFIG_HISTORY_DIR must be on a persistent volume
+//   MINIO_ENDPOINT: default MinIO server, host:port or https://host:port (default: localhost:9000)
+//   MINIO_USE_SSL / MINIO_REGION / MINIO_CA_FILE: default MinIO TLS, region and private CA bundle
+//   MINIO_ROOT_USER / MINIO_ROOT_PASSWORD: default MinIO credentials
+//   SIZE_CACHE_TTL: reuse of MinIO folder sizes and object stats, objects are revalidated by ETag after it (default: 1m, 0 disables)
+//   SIZE_CACHE_MAX_ENTRIES: maximum number of cached MinIO paths (default: 10000)
+//   IMAGE_CHECK_REGISTRIES: registries whose images are checked before submission, "docker.io,http://localhost:5000" (default: no check)
+//   IMAGE_CHECK_AUTH_FILE: docker config.json with registry credentials (default: anonymous)
+//   IMAGE_CHECK_CACHE_TTL: cache duration of found images (default: 10m)
+//   IMAGE_CHECK_TIMEOUT: registry request timeout (default: 5s)
+//   IDEMPOTENCY_TTL: how long create results are replayed for the same Idempotency-Key (default: 1h)
 package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"service-common/handlers"
 	"service-common/logger"
 	"service-common/middleware"
+	"service-common/openapi"
+	"service-common/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -37,6 +64,9 @@ import (
 const (
 	// ShutdownTimeout is the maximum time to wait for graceful shutdown
 	ShutdownTimeout = 30 * time.Second
+
+	// APIBasePath is the v1 API prefix (OpenAPI servers url)
+	APIBasePath = "/api/v1"
 )
 
 // getPort returns the port from environment variable or default
@@ -47,7 +77,70 @@ func getPort() string {
 	return ":8080"
 }
 
+// startConfigProvider starts the provisioning config source selected by CONFIG_SOURCE
+//   - file (default): config.json with hot reload
+//   - crd: ProvisionConfig custom resources (CONFIG_NAMESPACE, empty for all namespaces)
+//
+// Every applied config is recorded as a revision in CONFIG_HISTORY_DIR.
+func startConfigProvider(ctx context.Context, configFiles []string) (*services.ConfigHistory, error) {
+	historyDir := os.Getenv("CONFIG_HISTORY_DIR")
+	if historyDir == "" {
+		historyDir = services.DefaultConfigHistoryDir
+	}
+	history, err := services.OpenConfigHistory(historyDir, services.GetEnvInt("CONFIG_HISTORY_LIMIT", services.DefaultConfigHistoryLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	source := os.Getenv("CONFIG_SOURCE")
+	switch source {
+	case "", services.ConfigSourceFile:
+		reloadInterval := services.GetEnvDuration("CONFIG_RELOAD_INTERVAL", services.DefaultConfigReloadInterval)
+		_, err = services.StartConfigStore(ctx, configFiles, reloadInterval, history)
+	case services.ConfigSourceCRD:
+		namespace := os.Getenv("CONFIG_NAMESPACE")
+		logger.Logger.Info("Using ProvisionConfig resources as config source", zap.String("namespace", namespace))
+		_, err = services.StartCRDConfigProvider(ctx, namespace, history)
+	default:
+		err = fmt.Errorf("unknown CONFIG_SOURCE %q (file or crd)", source)
+	}
+	return history, err
+}
+
+// newImageChecker creates the registry image checker (nil when no registries are given)
+// IMAGE_CHECK_CACHE_TTL and IMAGE_CHECK_TIMEOUT are read from the environment
+func newImageChecker(registries []string, authFile string) (*services.ImageChecker, error) {
+	if len(registries) == 0 {
+		return nil, nil
+	}
+
+	var credentials map[string]services.RegistryCredential
+	if authFile != "" {
+		var err error
+		if credentials, err = services.LoadRegistryCredentials(authFile); err != nil {
+			return nil, err
+		}
+	}
+	return services.NewImageChecker(services.ImageCheckerOptions{
+		Registries:  registries,
+		Credentials: credentials,
+		CacheTTL:    services.GetEnvDuration("IMAGE_CHECK_CACHE_TTL", services.DefaultImageCheckCacheTTL),
+		Timeout:     services.GetEnvDuration("IMAGE_CHECK_TIMEOUT", services.DefaultImageCheckTimeout),
+	})
+}
+
 func main() {
+	// CLI subcommands (e.g. hynix config validate)
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		os.Exit(runCommand(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
+	opts, err := parseServerOptions(os.Args[1:], os.Stderr)
+	if err != nil {
+		os.Exit(2)
+	}
+	services.SetTemplateDir(opts.templateDir)
+
 	// Initialize logger
 	logger.Init()
 	defer logger.Sync()
@@ -57,10 +150,62 @@ func main() {
 	logger.Logger.Info("Starting Hynix microservice",
 		zap.String("port", port),
 		zap.String("version", "2.0"),
+		zap.Strings("config_files", opts.configFiles),
+		zap.String("template_dir", opts.templateDir),
 	)
 
+	// 프로비저닝 설정을 메모리에 캐시하고 변경 시 재로드
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	history, err := startConfigProvider(watchCtx, opts.configFiles)
+	if err != nil {
+		logger.Logger.Fatal("Config load failed", zap.Error(err))
+	}
+
+	// admin API 토큰 (비어 있으면 admin API 비활성화)
+	adminTokens, err := handlers.ParseAdminTokens(os.Getenv("ADMIN_TOKENS"))
+	if err != nil {
+		logger.Logger.Fatal("Invalid ADMIN_TOKENS", zap.Error(err))
+	}
+	// admin API가 config.json에 기록한 변경(빌드 번호 등)이 재시작 시 사라지지 않도록 영구 볼륨 필요
+	if store, ok := services.ActiveConfigProvider().(*services.ConfigStore); ok && len(adminTokens) > 0 {
+		if err := store.CheckPersistent(); err != nil {
+			logger.Logger.Fatal("Admin API requires a persistent config volume", zap.Error(err))
+		}
+	}
+
+	// MinIO 클라이언트는 서버(기본 또는 provision의 minio_server)별로 하나씩 만들어 재사용
+	minioConfig := services.MinIOConfigFromEnv()
+	services.SetMinIOConfig(minioConfig)
+	logger.Logger.Info("MinIO server", zap.String("minio_url", minioConfig.URL()), zap.String("region", minioConfig.Region))
+
+	// 입력 크기 캐시: 같은 경로를 반복 조회하는 reference/create 요청의 MinIO 호출을 줄임
+	if os.Getenv("SIZE_CACHE_TTL") != "0" {
+		sizeCache := services.NewSizeCache(
+			services.GetEnvDuration("SIZE_CACHE_TTL", services.DefaultSizeCacheTTL),
+			services.GetEnvInt("SIZE_CACHE_MAX_ENTRIES", services.DefaultSizeCacheMaxEntries),
+		)
+		services.SetSizeCache(sizeCache)
+		logger.Logger.Info("MinIO size cache", zap.Duration("ttl", sizeCache.TTL()), zap.Int("max_entries", sizeCache.MaxEntries()))
+	}
+
+	// 렌더링된 CR의 image가 레지스트리에 있는지 확인 (create는 없으면 거부, reference는 경고)
+	imageChecker, err := newImageChecker(services.SplitConfigFiles(os.Getenv("IMAGE_CHECK_REGISTRIES")), os.Getenv("IMAGE_CHECK_AUTH_FILE"))
+	if err != nil {
+		logger.Logger.Fatal("Image checker setup failed", zap.Error(err))
+	}
+	if imageChecker != nil {
+		logger.Logger.Info("Checking images against registries", zap.Strings("registries", imageChecker.Registries()))
+		services.SetImageChecker(imageChecker)
+	}
+
+	// create의 Idempotency-Key 결과 보관 기간
+	idempotencyStore := services.NewIdempotencyStore(services.GetEnvDuration("IDEMPOTENCY_TTL", handlers.DefaultIdempotencyTTL))
+	handlers.SetIdempotencyStore(idempotencyStore)
+	logger.Logger.Info("Idempotency store", zap.Duration("ttl", idempotencyStore.TTL()))
+
 	// Setup Gin router
-	router := setupRouter()
+	router := setupRouter(adminTokens, history)
 
 	// Setup server
 	server := &http.Server{
@@ -81,10 +226,17 @@ func main() {
 }
 
 // setupRouter configures and returns the Gin router with all routes and middleware
-func setupRouter() *gin.Engine {
+func setupRouter(adminTokens handlers.AdminTokens, history *services.ConfigHistory) *gin.Engine {
 	router := gin.Default()
 
+	// OpenAPI 문서 로드 (요청 검증 및 /api/v1/openapi.{yaml,json} 제공)
+	validator, err := openapi.NewValidator()
+	if err != nil {
+		logger.Logger.Fatal("OpenAPI spec load failed", zap.Error(err))
+	}
+
 	// Middleware
+	router.Use(middleware.RequestIDMiddleware())
 	router.Use(middleware.LoggingMiddleware())
 
 	// Health check endpoint
@@ -94,17 +246,61 @@ func setupRouter() *gin.Engine {
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	// API v1 routes
-	setupAPIRoutes(router)
+	setupAPIRoutes(router, validator, adminTokens, history)
+
+	// 핸들러와 OpenAPI 문서가 어긋나지 않도록 모든 v1 라우트가 문서에 있는지 확인
+	if err := validator.CheckRoutes(APIBasePath, registeredRoutes(router)); err != nil {
+		logger.Logger.Fatal("OpenAPI spec out of sync with routes", zap.Error(err))
+	}
+
+	// 등록되지 않은 경로도 공통 오류 envelope으로 응답
+	router.NoRoute(handlers.NotFound)
 
 	return router
 }
 
 // setupAPIRoutes configures API v1 route group
-func setupAPIRoutes(router *gin.Engine) {
-	api := router.Group("/api/v1")
+func setupAPIRoutes(router *gin.Engine, validator *openapi.Validator, adminTokens handlers.AdminTokens, history *services.ConfigHistory) {
+	// OpenAPI 요청 검증은 라우트 그룹별로 등록 (admin API는 인증 후에 검증)
+	api := router.Group(APIBasePath, handlers.OpenAPIValidationMiddleware(validator))
 	{
+		api.GET("/openapi.yaml", handlers.OpenAPISpecHandler(validator))
+		api.GET("/openapi.json", handlers.OpenAPISpecHandler(validator))
 		api.GET("/spark/reference", handlers.GetSparkReference)
+		api.POST("/spark/reference/batch", handlers.GetSparkReferenceBatch)
+		api.POST("/spark/create", handlers.CreateSparkApplicationIdempotent)
+		api.GET("/spark/applications", handlers.ListSparkApplications)
+		api.GET("/spark/applications/:service_id/:category/:uid", handlers.GetSparkApplication)
+		api.DELETE("/spark/applications/:service_id/:category/:uid", handlers.DeleteSparkApplication)
+		api.GET("/spark/applications/:service_id/:category/:uid/events", handlers.StreamSparkApplicationEvents)
+	}
+
+	// Provision admin API (Authorization: Bearer <ADMIN_TOKENS>)
+	// 인증되지 않은 요청에는 검증 오류(허용된 provision_id 목록 등) 대신 401을 반환
+	admin := router.Group(APIBasePath+"/admin", handlers.AdminAuthMiddleware(adminTokens), handlers.OpenAPIValidationMiddleware(validator))
+	{
+		admin.GET("/provisions", handlers.ListProvisions)
+		admin.POST("/provisions", handlers.CreateProvision)
+		admin.GET("/provisions/:provision_id", handlers.GetProvision)
+		admin.PUT("/provisions/:provision_id", handlers.UpdateProvision)
+		admin.POST("/provisions/:provision_id/disable", handlers.DisableProvision)
+		admin.POST("/provisions/:provision_id/build", handlers.SetProvisionBuild)
+		admin.POST("/provisions/:provision_id/canary/promote", handlers.PromoteCanary)
+		admin.POST("/provisions/:provision_id/canary/abort", handlers.AbortCanary)
+		admin.GET("/config/revisions", handlers.ListConfigRevisions(history))
+		admin.GET("/config/revisions/:revision", handlers.GetConfigRevision(history))
+		admin.POST("/config/revisions/:revision/rollback", handlers.RollbackConfig(history))
+		admin.GET("/config/diff", handlers.DiffConfigRevisions(history))
+	}
+}
+
+// registeredRoutes returns registered routes as "METHOD path"
+func registeredRoutes(router *gin.Engine) []string {
+	routes := make([]string, 0, len(router.Routes()))
+	for _, route := range router.Routes() {
+		routes = append(routes, route.Method+" "+route.Path)
 	}
+	return routes
 }
 
 // GracefulShutdown handles graceful server shutdown
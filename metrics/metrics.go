@@ -25,6 +25,15 @@ var (
 		[]string{"provision_id", "endpoint"},
 	)
 
+	// ErrorsTotal - 오류 코드별 오류 응답 수
+	ErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spark_service_errors_total",
+			Help: "Total number of error responses by stable error code",
+		},
+		[]string{"provision_id", "endpoint", "code"},
+	)
+
 	// QueueSelection - 큐 선택 수
 	QueueSelection = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -34,6 +43,41 @@ var (
 		[]string{"provision_id", "queue"},
 	)
 
+	// BuildSelection - 빌드 선택 수 (카나리 진행 중이면 stable/canary 비율 확인용)
+	BuildSelection = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spark_service_build_selection_total",
+			Help: "Total number of rendered SparkApplications by build version and track (stable/canary)",
+		},
+		[]string{"provision_id", "build", "track"},
+	)
+
+	// ImageChecks - 레지스트리 이미지 확인 결과 (cache: hit/miss)
+	ImageChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spark_service_image_checks_total",
+			Help: "Total number of container image manifest checks by registry, result (found/missing/error) and cache (hit/miss)",
+		},
+		[]string{"registry", "result", "cache"},
+	)
+
+	// SizeCacheRequests - MinIO 크기 캐시 조회 결과 (kind: object/folder, result: hit/miss/revalidated/bypass)
+	SizeCacheRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spark_service_size_cache_requests_total",
+			Help: "Total number of MinIO size lookups by kind (object/folder) and cache result (hit/miss/revalidated/bypass)",
+		},
+		[]string{"kind", "result"},
+	)
+
+	// SizeCacheEntries - MinIO 크기 캐시 항목 수
+	SizeCacheEntries = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "spark_service_size_cache_entries",
+			Help: "Number of cached MinIO folder sizes and object stats",
+		},
+	)
+
 	// ProvisionMode - 프로비저닝 모드 사용 현황
 	ProvisionMode = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -70,6 +114,67 @@ var (
 		[]string{"provision_id", "namespace"},
 	)
 
+	// DryRunValidation - Kubernetes API dry-run 검증 결과
+	DryRunValidation = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spark_service_dry_run_validation_total",
+			Help: "Total number of server-side dry-run validations by result (valid/invalid/error)",
+		},
+		[]string{"provision_id", "endpoint", "result"},
+	)
+
+	// IdempotencyHits - Idempotency-Key 재요청으로 저장된 결과를 반환한 횟수
+	IdempotencyHits = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spark_service_idempotency_hits_total",
+			Help: "Total number of requests answered from a stored Idempotency-Key result",
+		},
+		[]string{"endpoint"},
+	)
+
+	// ConfigReloads - config.json 재로드 결과 (success/invalid)
+	ConfigReloads = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spark_service_config_reloads_total",
+			Help: "Total number of config.json reloads by result (success/invalid)",
+		},
+		[]string{"result"},
+	)
+
+	// ConfigLastReloadSuccess - 마지막으로 config.json 재로드에 성공한 시각
+	ConfigLastReloadSuccess = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "spark_service_config_last_reload_success_timestamp_seconds",
+			Help: "Unix timestamp of the last successful config.json load",
+		},
+	)
+
+	// ConfigReloadFailing - 디스크의 config.json이 검증에 실패해 이전 설정을 사용 중이면 1
+	ConfigReloadFailing = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "spark_service_config_reload_failing",
+			Help: "1 if the config.json on disk failed validation and the last good config is still served",
+		},
+	)
+
+	// ConfigLegacySpecs - 레거시 resource_calculation(threshold/min_queue/max_queue)을 사용하는 프로비저닝이면 1
+	ConfigLegacySpecs = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "spark_service_config_legacy_resource_calculation",
+			Help: "1 for each provision whose resource_calculation uses the deprecated threshold/min_queue/max_queue format",
+		},
+		[]string{"provision_id"},
+	)
+
+	// ProvisionConfigObjects - 검증 결과별 ProvisionConfig 리소스 수 (CONFIG_SOURCE=crd)
+	ProvisionConfigObjects = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "spark_service_provision_config_objects",
+			Help: "Number of ProvisionConfig resources by validation state (valid/invalid)",
+		},
+		[]string{"state"},
+	)
+
 	// FileSize - 파일 크기 (MB)
 	FileSize = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
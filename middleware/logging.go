@@ -20,6 +20,7 @@ func LoggingMiddleware() gin.HandlerFunc {
 			zap.Int("status", c.Writer.Status()),
 			zap.String("query", c.Request.URL.RawQuery),
 			zap.String("client_ip", c.ClientIP()),
+			zap.String("request_id", GetRequestID(c)),
 		)
 	}
 }
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// RequestIDHeader - 요청 ID 헤더 (클라이언트가 보낸 값이 있으면 그대로 사용)
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey - gin.Context에 저장하는 요청 ID 키
+	RequestIDKey = "request_id"
+
+	// maxRequestIDLength - 클라이언트가 보낸 요청 ID 최대 길이 (초과 시 새로 생성)
+	maxRequestIDLength = 128
+)
+
+// RequestIDMiddleware - 요청 ID 부여 미들웨어
+// 오류 응답과 로그에서 같은 요청을 추적할 수 있도록 요청 ID를 context와 응답 헤더에 설정
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// GetRequestID - gin.Context에서 요청 ID 조회
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(RequestIDKey)
+}
@@ -0,0 +1,201 @@
+// Package openapi provides the OpenAPI 3 document of the v1 API and
+// validates incoming requests against it.
+//
+// The document (openapi.yaml) is embedded into the binary and served by the
+// service, so the contract clients read is the same one requests are checked
+// against.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"service-common/services"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// ProvisionIDFormat - config.json에 정의된 provision_id만 허용하는 문자열 format
+const ProvisionIDFormat = "provision-id"
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+// FieldError - 요청 검증 실패 항목
+type FieldError struct {
+	In     string `json:"in"`              // query, path, header, body
+	Field  string `json:"field,omitempty"` // 파라미터 이름 또는 본문 JSON 경로 (예: items/0/uid)
+	Reason string `json:"reason"`
+}
+
+// Validator - OpenAPI 문서 기반 요청 검증기
+type Validator struct {
+	doc    *openapi3.T
+	router routers.Router
+	json   []byte
+}
+
+// SpecYAML - 임베드된 OpenAPI 문서 원본 (YAML)
+func SpecYAML() []byte {
+	return specYAML
+}
+
+// NewValidator - 임베드된 OpenAPI 문서를 로드/검증하고 요청 검증기 생성
+func NewValidator() (*Validator, error) {
+	openapi3.DefineStringFormatValidator(ProvisionIDFormat, openapi3.NewCallbackValidator(validateProvisionID))
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(specYAML)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAPI 문서 로드 실패: %w", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("OpenAPI 문서 검증 실패: %w", err)
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAPI 라우터 생성 실패: %w", err)
+	}
+
+	specJSON, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("OpenAPI 문서 JSON 변환 실패: %w", err)
+	}
+
+	return &Validator{doc: doc, router: router, json: specJSON}, nil
+}
+
+// SpecJSON - OpenAPI 문서 (JSON)
+func (v *Validator) SpecJSON() []byte {
+	return v.json
+}
+
+// ValidateRequest - 요청을 OpenAPI 문서에 대해 검증
+// 문서에 없는 경로이면 matched=false (health, metrics 등은 검증하지 않음)
+// operationID는 메트릭/로그의 endpoint 라벨로 사용
+func (v *Validator) ValidateRequest(r *http.Request) (operationID string, fieldErrors []FieldError, matched bool) {
+	route, pathParams, err := v.router.FindRoute(r)
+	if err != nil {
+		return "", nil, false
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			MultiError:          true,
+			SkipSettingDefaults: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+
+	if err := openapi3filter.ValidateRequest(context.Background(), input); err != nil {
+		fieldErrors = collectFieldErrors(err, nil)
+	}
+	return route.Operation.OperationID, fieldErrors, true
+}
+
+// CheckRoutes - 등록된 라우트가 모두 OpenAPI 문서에 정의되어 있는지 확인
+// routes는 "METHOD /api/v1/path/:param" 형식 (gin 경로 표기)
+func (v *Validator) CheckRoutes(basePath string, routes []string) error {
+	var missing []string
+	for _, route := range routes {
+		method, path, ok := strings.Cut(route, " ")
+		if !ok || !strings.HasPrefix(path, basePath) {
+			continue
+		}
+		specPath := toSpecPath(strings.TrimPrefix(path, basePath))
+		pathItem := v.doc.Paths.Value(specPath)
+		if pathItem == nil || pathItem.GetOperation(method) == nil {
+			missing = append(missing, route)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("OpenAPI 문서에 정의되지 않은 라우트: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// toSpecPath - gin 경로 파라미터(:name)를 OpenAPI 표기({name})로 변환
+func toSpecPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + strings.TrimPrefix(segment, ":") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// validateProvisionID - provision_id가 config.json에 정의되어 있는지 확인
+// config 로드 실패는 여기서 판단하지 않고 핸들러가 CONFIG_UNAVAILABLE로 응답하도록 통과시킴
+func validateProvisionID(provisionID string) error {
+	config, err := services.LoadConfig()
+	if err != nil {
+		return nil
+	}
+	if _, err := services.FindProvisionConfig(config, provisionID); err == nil {
+		return nil
+	}
+
+	allowed := make([]string, 0, len(config.ConfigSpecs))
+	for _, spec := range config.ConfigSpecs {
+		allowed = append(allowed, spec.ProvisionID)
+	}
+	return fmt.Errorf("허용되지 않은 provision_id입니다 (허용: %s)", strings.Join(allowed, ", "))
+}
+
+// collectFieldErrors - kin-openapi 검증 오류를 필드 단위 오류 목록으로 변환
+// RequestError(파라미터/본문) 안에 MultiError/SchemaError가 중첩되므로 바깥부터 순서대로 펼침
+func collectFieldErrors(err error, parent *FieldError) []FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var fieldErrors []FieldError
+		for _, inner := range e {
+			fieldErrors = append(fieldErrors, collectFieldErrors(inner, parent)...)
+		}
+		return fieldErrors
+
+	case *openapi3filter.RequestError:
+		fieldError := FieldError{In: "body", Reason: e.Reason}
+		if e.Parameter != nil {
+			fieldError.In = e.Parameter.In
+			fieldError.Field = e.Parameter.Name
+		}
+		if e.Err == nil {
+			return []FieldError{fieldError}
+		}
+		return collectFieldErrors(e.Err, &fieldError)
+	}
+
+	fieldError := FieldError{In: "body"}
+	if parent != nil {
+		fieldError = *parent
+	}
+	fieldError.Reason = err.Error()
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		fieldError.Reason = schemaErr.Reason
+		if schemaErr.Origin != nil {
+			fieldError.Reason = schemaErr.Origin.Error()
+		}
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 && fieldError.In == "body" {
+			fieldError.Field = strings.Join(pointer, "/")
+		}
+	}
+
+	return []FieldError{fieldError}
+}
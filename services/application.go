@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// LabelProvisionID - SparkApplication의 프로비저닝 ID 라벨 키
+	LabelProvisionID = "provision-id"
+	// LabelCategory - SparkApplication의 category 라벨 키
+	LabelCategory = "category"
+	// LabelBuildNumber - SparkApplication의 빌드 번호 라벨 키 (템플릿의 build-number 라벨)
+	LabelBuildNumber = "build-number"
+	// LabelBuildTrack - SparkApplication의 빌드 트랙 라벨 키 (stable, canary)
+	LabelBuildTrack = "build-track"
+	// AnnotationConfigRevision - SparkApplication 렌더링에 사용한 설정 revision annotation 키
+	AnnotationConfigRevision = "hynix.io/config-revision"
+	// AnnotationSubmissionID - 교체 제출마다 바뀌는 spec.driver.annotations 키 (spec이 같아도 operator가 다시 실행하도록)
+	AnnotationSubmissionID = "hynix.io/submission-id"
+
+	// DefaultNamespace - 네임스페이스 미지정 시 사용하는 기본 네임스페이스
+	DefaultNamespace = "default"
+)
+
+// SparkApplicationSummary - SparkApplication 상태 요약
+type SparkApplicationSummary struct {
+	Name               string            `json:"name"`
+	Namespace          string            `json:"namespace"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	State              string            `json:"state"` // status.applicationState.state (제출 전이면 빈 문자열)
+	ErrorMessage       string            `json:"error_message,omitempty"`
+	SparkAppID         string            `json:"spark_application_id,omitempty"`
+	DriverPodName      string            `json:"driver_pod_name,omitempty"`
+	ExecutorState      map[string]string `json:"executor_state,omitempty"` // executor pod 이름 → 상태
+	SubmissionAttempts int64             `json:"submission_attempts"`
+	SubmissionTime     string            `json:"submission_time,omitempty"`
+	TerminationTime    string            `json:"termination_time,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+}
+
+// GetSparkApplication - 이름으로 SparkApplication 조회 후 상태 요약 반환
+func GetSparkApplication(namespace, name string) (*SparkApplicationSummary, error) {
+	// 클라이언트 초기화
+	if err := initK8sClient(); err != nil {
+		return nil, err
+	}
+
+	u, err := getSparkApplication(context.Background(), namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeSparkApplication(u), nil
+}
+
+// ListSparkApplications - 라벨 셀렉터로 SparkApplication 목록 조회
+// labels가 비어있으면 네임스페이스의 모든 SparkApplication 반환
+func ListSparkApplications(namespace string, labels map[string]string) ([]SparkApplicationSummary, error) {
+	// 클라이언트 초기화
+	if err := initK8sClient(); err != nil {
+		return nil, err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(sparkApplicationGVK.GroupVersion().WithKind(sparkApplicationGVK.Kind + "List"))
+
+	opts := []client.ListOption{client.InNamespace(namespace)}
+	if len(labels) > 0 {
+		opts = append(opts, client.MatchingLabels(labels))
+	}
+
+	if err := k8sClient.List(context.Background(), list, opts...); err != nil {
+		return nil, fmt.Errorf("SparkApplication 목록 조회 실패: %w", err)
+	}
+
+	summaries := make([]SparkApplicationSummary, 0, len(list.Items))
+	for i := range list.Items {
+		summaries = append(summaries, *summarizeSparkApplication(&list.Items[i]))
+	}
+	return summaries, nil
+}
+
+// DeleteSparkApplication - 이름으로 SparkApplication 삭제
+// 삭제 직전 상태 요약을 반환 (메트릭 라벨 등에 사용)
+func DeleteSparkApplication(namespace, name string) (*SparkApplicationSummary, error) {
+	// 클라이언트 초기화
+	if err := initK8sClient(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	u, err := getSparkApplication(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := k8sClient.Delete(ctx, u); err != nil {
+		return nil, fmt.Errorf("SparkApplication 삭제 실패: %w", err)
+	}
+
+	log.Printf("SparkApplication 삭제됨: %s/%s", namespace, name)
+	return summarizeSparkApplication(u), nil
+}
+
+// IsNotFoundError - Kubernetes 리소스가 존재하지 않아 발생한 오류인지 확인
+func IsNotFoundError(err error) bool {
+	return apierrors.IsNotFound(err)
+}
+
+// getSparkApplication - SparkApplication Unstructured 조회
+func getSparkApplication(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(sparkApplicationGVK)
+
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, u); err != nil {
+		return nil, fmt.Errorf("SparkApplication %s/%s 조회 실패: %w", namespace, name, err)
+	}
+	return u, nil
+}
+
+// summarizeSparkApplication - SparkApplication status에서 상태 요약 추출
+func summarizeSparkApplication(u *unstructured.Unstructured) *SparkApplicationSummary {
+	summary := &SparkApplicationSummary{
+		Name:      u.GetName(),
+		Namespace: u.GetNamespace(),
+		Labels:    u.GetLabels(),
+		CreatedAt: u.GetCreationTimestamp().Time,
+	}
+
+	summary.State, _, _ = unstructured.NestedString(u.Object, "status", "applicationState", "state")
+	summary.ErrorMessage, _, _ = unstructured.NestedString(u.Object, "status", "applicationState", "errorMessage")
+	summary.SparkAppID, _, _ = unstructured.NestedString(u.Object, "status", "sparkApplicationId")
+	summary.DriverPodName, _, _ = unstructured.NestedString(u.Object, "status", "driverInfo", "podName")
+	summary.SubmissionAttempts, _, _ = unstructured.NestedInt64(u.Object, "status", "submissionAttempts")
+	summary.SubmissionTime, _, _ = unstructured.NestedString(u.Object, "status", "lastSubmissionAttemptTime")
+	summary.TerminationTime, _, _ = unstructured.NestedString(u.Object, "status", "terminationTime")
+
+	if executorState, found, _ := unstructured.NestedStringMap(u.Object, "status", "executorState"); found {
+		summary.ExecutorState = executorState
+	}
+
+	return summary
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultVersionFormat - version_format 기본값 (major=4, patch=1 고정, minor=빌드 번호)
+	DefaultVersionFormat = "4.{number}.1"
+	// DefaultTagFormat - tag_format 기본값 (버전을 그대로 image 태그로 사용)
+	DefaultTagFormat = "{version}"
+	// DefaultLabelFormat - label_format 기본값 (버전을 그대로 build-number 라벨 값으로 사용)
+	DefaultLabelFormat = "{version}"
+
+	// buildNumberPlaceholder - 템플릿의 빌드 번호 플레이스홀더
+	buildNumberPlaceholder = "BUILD_NUMBER"
+)
+
+var (
+	// buildFormatPattern - 형식 문자열의 {name} 플레이스홀더
+	buildFormatPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+	// imageTagPattern - OCI 이미지 태그 형식
+	imageTagPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+)
+
+// ImageSettings - 프로비저닝의 이미지 저장소와 버전 형식 (지정하지 않은 값은 기본값 사용)
+// 형식에는 {number}(빌드 번호)를, tag_format/label_format에는 {version}(version_format 결과)도 사용할 수 있음
+type ImageSettings struct {
+	Repository    string `json:"repository,omitempty"`     // BUILD_NUMBER를 사용하는 image의 저장소 (예: harbor.example.com/spark, 비어 있으면 템플릿 값)
+	VersionFormat string `json:"version_format,omitempty"` // 기본 "4.{number}.1"
+	TagFormat     string `json:"tag_format,omitempty"`     // image 태그, 기본 "{version}"
+	LabelFormat   string `json:"label_format,omitempty"`   // build-number 라벨 값, 기본 "{version}"
+	DigestOnly    bool   `json:"digest_only,omitempty"`    // digest가 있으면 태그 없이 repository@digest로 참조
+}
+
+// BuildImage - 선택된 빌드로 만든 버전, image 태그, 라벨 값
+type BuildImage struct {
+	Version string // version_format 결과 (템플릿의 그 외 BUILD_NUMBER에 대입)
+	Tag     string // image 태그
+	Label   string // build-number 라벨 값
+	Digest  string // image 고정 digest (없으면 빈 문자열)
+}
+
+// Build - 빌드 번호와 digest로 버전/태그/라벨 값 생성 (s가 nil이면 기본 형식)
+// 예: 기본 형식, number="13" → version=tag=label="4.13.1"
+func (s *ImageSettings) Build(number, digest string) BuildImage {
+	versionFormat, tagFormat, labelFormat := DefaultVersionFormat, DefaultTagFormat, DefaultLabelFormat
+	if s != nil && s.VersionFormat != "" {
+		versionFormat = s.VersionFormat
+	}
+	if s != nil && s.TagFormat != "" {
+		tagFormat = s.TagFormat
+	}
+	if s != nil && s.LabelFormat != "" {
+		labelFormat = s.LabelFormat
+	}
+
+	version := expandBuildFormat(versionFormat, number, "")
+	return BuildImage{
+		Version: version,
+		Tag:     expandBuildFormat(tagFormat, number, version),
+		Label:   expandBuildFormat(labelFormat, number, version),
+		Digest:  digest,
+	}
+}
+
+// expandBuildFormat - 형식 문자열의 {number}, {version} 치환
+func expandBuildFormat(format, number, version string) string {
+	return strings.NewReplacer("{number}", number, "{version}", version).Replace(format)
+}
+
+// buildFormatPlaceholders - 형식 문자열에 사용된 플레이스홀더 이름
+func buildFormatPlaceholders(format string) []string {
+	var names []string
+	for _, match := range buildFormatPattern.FindAllStringSubmatch(format, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// ApplyBuildToYAML - 템플릿의 BUILD_NUMBER를 빌드 값으로 교체
+//   - image: 저장소(repository 설정 시 교체)와 태그, digest가 있으면 @digest로 고정
+//   - build-number 라벨: label 값
+//   - 그 외: version 값
+//
+// BUILD_NUMBER를 사용하지 않는 image(사이드카 등)는 변경하지 않음
+func ApplyBuildToYAML(yamlStr string, settings *ImageSettings, image BuildImage) string {
+	lines := strings.Split(yamlStr, "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, buildNumberPlaceholder) {
+			continue
+		}
+		trimmed := strings.TrimPrefix(strings.TrimSpace(line), "- ")
+		switch {
+		case strings.HasPrefix(trimmed, "image:"):
+			lines[i] = buildImageLine(line, settings, image)
+		case strings.HasPrefix(trimmed, LabelBuildNumber+":"):
+			lines[i] = strings.ReplaceAll(line, buildNumberPlaceholder, image.Label)
+		default:
+			lines[i] = strings.ReplaceAll(line, buildNumberPlaceholder, image.Version)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// buildImageLine - image 값을 저장소:태그[@digest] 또는 저장소@digest로 교체 (따옴표는 유지)
+// 예: spark:BUILD_NUMBER → harbor.example.com/spark:4.13.1@sha256:...
+func buildImageLine(line string, settings *ImageSettings, image BuildImage) string {
+	prefix, value, _ := strings.Cut(line, "image:")
+	value = strings.TrimSpace(value)
+	quote := ""
+	if n := len(value); n >= 2 && (value[0] == '"' || value[0] == '\'') && value[n-1] == value[0] {
+		quote, value = value[:1], value[1:n-1]
+	}
+
+	slash, colon := strings.LastIndex(value, "/"), strings.LastIndex(value, ":")
+	if colon <= slash || !strings.Contains(value[colon+1:], buildNumberPlaceholder) {
+		// 태그가 아닌 곳에 BUILD_NUMBER가 있는 경우 태그 값으로 치환만 함
+		return strings.ReplaceAll(line, buildNumberPlaceholder, image.Tag)
+	}
+
+	repository := value[:colon]
+	if settings != nil && settings.Repository != "" {
+		repository = settings.Repository
+	}
+	ref := repository + ":" + strings.ReplaceAll(value[colon+1:], buildNumberPlaceholder, image.Tag)
+	if image.Digest != "" {
+		if settings != nil && settings.DigestOnly {
+			ref = repository + "@" + image.Digest
+		} else {
+			ref += "@" + image.Digest
+		}
+	}
+	return prefix + "image: " + quote + ref + quote
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"time"
+)
+
+const (
+	// BuildTrackStable - build_number.number (안정 빌드)를 사용
+	BuildTrackStable = "stable"
+	// BuildTrackCanary - build_number.canary.number (카나리 빌드)를 사용
+	BuildTrackCanary = "canary"
+
+	// canaryBuckets - service_id 해시 버킷 수 (percent 단위)
+	canaryBuckets = 100
+)
+
+// imageDigestPattern - 이미지 digest 형식 (sha256:<64 hex>)
+var imageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// ErrCanaryNotActive - promote/abort 대상 카나리 빌드가 없음
+var ErrCanaryNotActive = errors.New("진행 중인 카나리 빌드가 없습니다")
+
+// BuildCanary - 카나리 빌드 설정
+// categories에 포함된 category이거나 service_id 해시 버킷이 percent 미만이면 카나리 빌드 사용
+type BuildCanary struct {
+	Number     string   `json:"number"`
+	Digest     string   `json:"digest,omitempty"`     // 카나리 빌드 이미지 digest
+	Percent    int      `json:"percent,omitempty"`    // 0~100, service_id 기준 (같은 service_id는 항상 같은 빌드)
+	Categories []string `json:"categories,omitempty"` // percent와 관계없이 카나리 빌드를 사용할 category
+}
+
+// BuildSelection - 요청에 사용할 빌드 선택 결과
+type BuildSelection struct {
+	Number string // 선택된 빌드 번호 (minor)
+	Digest string // 선택된 빌드의 이미지 digest (없으면 빈 문자열)
+	Track  string // stable, canary
+	Reason string // 선택 이유 (decision trace 용)
+}
+
+// SelectBuild - 카나리 설정에 따라 요청에 사용할 빌드 선택
+// 해시는 service_id만 사용하므로 percent를 올려도 이미 카나리인 service_id는 계속 카나리로 남음
+func SelectBuild(bn BuildNumber, serviceID, category string) BuildSelection {
+	stable := BuildSelection{Number: bn.Number, Digest: bn.Digest, Track: BuildTrackStable}
+	canary := bn.Canary
+	if canary == nil {
+		return stable
+	}
+
+	if slices.Contains(canary.Categories, category) {
+		return BuildSelection{Number: canary.Number, Digest: canary.Digest, Track: BuildTrackCanary, Reason: fmt.Sprintf("category %s는 카나리 대상", category)}
+	}
+
+	bucket := CanaryBucket(serviceID)
+	if bucket < canary.Percent {
+		return BuildSelection{Number: canary.Number, Digest: canary.Digest, Track: BuildTrackCanary, Reason: fmt.Sprintf("service_id 버킷 %d < %d%%", bucket, canary.Percent)}
+	}
+	stable.Reason = fmt.Sprintf("service_id 버킷 %d >= %d%%", bucket, canary.Percent)
+	return stable
+}
+
+// CanaryBucket - service_id의 카나리 버킷 (0~99, FNV-1a 해시)
+func CanaryBucket(serviceID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(serviceID))
+	return int(h.Sum32() % canaryBuckets)
+}
+
+// SetBuild - CI가 빌드한 번호/digest를 프로비저닝에 설정하고 변경한 사용자와 시각 기록
+// canary가 있으면 안정 빌드는 그대로 두고 canary.number/digest로 카나리를 시작(또는 교체)함
+// updatedBy(CI 작업 등)가 비어 있으면 author(토큰 이름)를 build_number.updated_by로 기록하며, revision 작성자는 항상 author
+// 번호와 digest가 현재 값과 같으면 변경하지 않음 (webhook 재전송)
+func SetBuild(author, updatedBy, provisionID, ifMatch, number, digest string, canary *BuildCanary) (*ConfigSpec, ConfigIssues, error) {
+	if updatedBy == "" {
+		updatedBy = author
+	}
+	message := fmt.Sprintf("build %s %s", provisionID, number)
+	if canary != nil {
+		message += " (canary)"
+	}
+	change := ConfigChange{Source: ConfigChangeAdmin, Author: author, Message: message}
+	return updateProvision(change, func(config *Config) (int, error) {
+		index, err := matchProvision(config, provisionID, ifMatch)
+		if err != nil {
+			return 0, err
+		}
+		bn := &config.ConfigSpecs[index].BuildNumber
+		if canary != nil {
+			next := *canary
+			next.Number, next.Digest = number, digest
+			if bn.Canary != nil && equalBuildCanary(bn.Canary, &next) {
+				return index, nil
+			}
+			bn.Canary = &next
+		} else {
+			if bn.Number == number && bn.Digest == digest {
+				return index, nil
+			}
+			bn.Number, bn.Digest = number, digest
+		}
+		markBuildUpdated(bn, updatedBy)
+		return index, nil
+	})
+}
+
+// PromoteCanary - 카나리 빌드를 안정 빌드로 승격 (number/digest=canary 값, canary 삭제)
+// ifMatch가 비어 있으면 ETag를 확인하지 않음
+func PromoteCanary(author, provisionID, ifMatch string) (*ConfigSpec, ConfigIssues, error) {
+	return updateCanary(author, provisionID, ifMatch, "promote", func(bn *BuildNumber) {
+		bn.Number, bn.Digest = bn.Canary.Number, bn.Canary.Digest
+		bn.Canary = nil
+		markBuildUpdated(bn, author)
+	})
+}
+
+// AbortCanary - 카나리 빌드 중단 (canary 삭제, 모든 요청이 안정 빌드 사용)
+func AbortCanary(author, provisionID, ifMatch string) (*ConfigSpec, ConfigIssues, error) {
+	return updateCanary(author, provisionID, ifMatch, "abort", func(bn *BuildNumber) {
+		bn.Canary = nil
+		markBuildUpdated(bn, author)
+	})
+}
+
+// updateCanary - 프로비저닝의 build_number.canary 변경 (overrides의 build_number는 변경하지 않음)
+func updateCanary(author, provisionID, ifMatch, action string, mutate func(bn *BuildNumber)) (*ConfigSpec, ConfigIssues, error) {
+	change := ConfigChange{Source: ConfigChangeAdmin, Author: author, Message: fmt.Sprintf("canary %s %s", action, provisionID)}
+	return updateProvision(change, func(config *Config) (int, error) {
+		index, err := matchProvision(config, provisionID, ifMatch)
+		if err != nil {
+			return 0, err
+		}
+		bn := &config.ConfigSpecs[index].BuildNumber
+		if bn.Canary == nil {
+			return 0, ErrCanaryNotActive
+		}
+		mutate(bn)
+		return index, nil
+	})
+}
+
+// markBuildUpdated - build_number(안정/카나리)를 변경한 사용자와 시각 기록
+func markBuildUpdated(bn *BuildNumber, author string) {
+	now := time.Now().UTC().Truncate(time.Second)
+	bn.UpdatedBy = author
+	bn.UpdatedAt = &now
+}
+
+// equalBuildCanary - 카나리 설정이 같은지 확인
+func equalBuildCanary(a, b *BuildCanary) bool {
+	return a.Number == b.Number && a.Digest == b.Digest && a.Percent == b.Percent && slices.Equal(a.Categories, b.Categories)
+}
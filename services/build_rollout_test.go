@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// testServiceIDs - 실제 service_id와 비슷한 형식의 테스트 ID (숫자 접두사 + 일련번호)
+func testServiceIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%04d_svc_%d", i%37, i)
+	}
+	return ids
+}
+
+func TestCanaryBucketDistribution(t *testing.T) {
+	const n = 20000
+	ids := testServiceIDs(n)
+
+	counts := make([]int, canaryBuckets)
+	for _, id := range ids {
+		bucket := CanaryBucket(id)
+		if bucket < 0 || bucket >= canaryBuckets {
+			t.Fatalf("CanaryBucket(%q) = %d, want 0~%d", id, bucket, canaryBuckets-1)
+		}
+		if again := CanaryBucket(id); again != bucket {
+			t.Fatalf("CanaryBucket(%q) is not stable: %d, %d", id, bucket, again)
+		}
+		counts[bucket]++
+	}
+
+	// 버킷당 기대값 200, 모든 버킷이 ±40% 안에 있어야 함
+	expected := float64(n) / canaryBuckets
+	for bucket, count := range counts {
+		if math.Abs(float64(count)-expected) > expected*0.4 {
+			t.Errorf("bucket %d has %d ids, want about %.0f", bucket, count, expected)
+		}
+	}
+
+	// percent만큼의 service_id가 카나리로 선택되어야 함 (±1.5%p)
+	for _, percent := range []int{1, 5, 10, 25, 50, 90} {
+		canary := 0
+		for _, id := range ids {
+			if CanaryBucket(id) < percent {
+				canary++
+			}
+		}
+		got := float64(canary) * 100 / n
+		if math.Abs(got-float64(percent)) > 1.5 {
+			t.Errorf("percent %d selects %.2f%% of service_ids", percent, got)
+		}
+	}
+}
+
+func TestSelectBuild(t *testing.T) {
+	serviceID := "0001_svc_1"
+	bucket := CanaryBucket(serviceID)
+
+	tests := []struct {
+		name       string
+		canary     *BuildCanary
+		category   string
+		wantTrack  string
+		wantNumber string
+	}{
+		{name: "no canary", wantTrack: BuildTrackStable, wantNumber: "13"},
+		{name: "zero percent", canary: &BuildCanary{Number: "14"}, wantTrack: BuildTrackStable, wantNumber: "13"},
+		{name: "full rollout", canary: &BuildCanary{Number: "14", Percent: 100}, wantTrack: BuildTrackCanary, wantNumber: "14"},
+		{name: "bucket below percent", canary: &BuildCanary{Number: "14", Percent: bucket + 1}, wantTrack: BuildTrackCanary, wantNumber: "14"},
+		{name: "bucket at percent", canary: &BuildCanary{Number: "14", Percent: bucket}, wantTrack: BuildTrackStable, wantNumber: "13"},
+		{name: "canary category", canary: &BuildCanary{Number: "14", Categories: []string{"tttm"}}, category: "tttm", wantTrack: BuildTrackCanary, wantNumber: "14"},
+		{name: "other category", canary: &BuildCanary{Number: "14", Categories: []string{"tttm"}}, category: "wfbm", wantTrack: BuildTrackStable, wantNumber: "13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bn := BuildNumber{Number: "13", Canary: tt.canary}
+			got := SelectBuild(bn, serviceID, tt.category)
+			if got.Track != tt.wantTrack || got.Number != tt.wantNumber {
+				t.Errorf("SelectBuild = %+v, want %s %s", got, tt.wantTrack, tt.wantNumber)
+			}
+		})
+	}
+}
+
+func TestSelectBuildRaisingPercentKeepsCanaries(t *testing.T) {
+	ids := testServiceIDs(1000)
+	for percent := 1; percent < canaryBuckets; percent++ {
+		lower := BuildNumber{Number: "13", Canary: &BuildCanary{Number: "14", Percent: percent}}
+		higher := BuildNumber{Number: "13", Canary: &BuildCanary{Number: "14", Percent: percent + 1}}
+		for _, id := range ids {
+			if SelectBuild(lower, id, "").Track == BuildTrackCanary && SelectBuild(higher, id, "").Track != BuildTrackCanary {
+				t.Fatalf("%s left the canary when percent went %d → %d", id, percent, percent+1)
+			}
+		}
+	}
+}
@@ -2,29 +2,32 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
 // Config - 설정 파일 구조체
 type Config struct {
 	ConfigSpecs []ConfigSpec `json:"config_specs"`
+
+	Revision int64 `json:"-"` // 적용 시 기록된 설정 revision (ConfigHistory, 기록하지 않았으면 0)
 }
 
 // ConfigSpec - 프로비저닝 설정
 type ConfigSpec struct {
 	ProvisionID         string              `json:"provision_id"`
-	Enabled             string              `json:"enabled"`
+	Enabled             bool                `json:"enabled"` // 하위 호환: "true"/"false" 문자열도 허용
 	ResourceCalculation ResourceCalculation `json:"resource_calculation"`
 	GangScheduling      GangScheduling      `json:"gang_scheduling"`
 	BuildNumber         BuildNumber         `json:"build_number"`
+	Image               *ImageSettings      `json:"image,omitempty"`     // 이미지 저장소와 버전/태그/라벨 형식 (없으면 4.{number}.1)
+	Overrides           []ProvisionOverride `json:"overrides,omitempty"` // category/region별 설정 교체 (ResolveProvision)
 }
 
 // ResourceTier - 리소스 계산 티어
@@ -33,26 +36,48 @@ type ResourceTier struct {
 	MinSize  int64  `json:"min_size,omitempty"`
 	MaxSize  int64  `json:"max_size,omitempty"`
 	Queue    string `json:"queue"`
-	Executor interface{} `json:"executor"` // 숫자 또는 문자열 지원
+	Executor int    `json:"executor"` // 하위 호환: "2" 같은 숫자 문자열도 허용
 }
 
 // TierSelectionResult - 티어 선택 결과
 type TierSelectionResult struct {
-	Queue        string
-	Executor     string // executor 개수 (문자열에서 int로 변환 필요)
-	ExecutorInt  int    // executor 개수 (정수)
-	TotalSize    int64
-	Metadata     *MinIOMetadata
-	ObjectCount  int
+	Queue       string
+	Executor    int // executor 개수
+	TotalSize   int64
+	Metadata    *MinIOMetadata
+	ObjectCount int
+	MinioPath   string           // <<service_id>> 치환 후 실제 조회한 MinIO 경로
+	MinioURL    string           // 조회한 MinIO 서버 (minio_server 또는 MINIO_ENDPOINT)
+	SizeCache   string           // 크기 캐시 결과 (hit, miss, revalidated, bypass, 캐시를 사용하지 않으면 빈 문자열)
+	TierName    string           // 선택된 티어 이름
+	Evaluations []TierEvaluation // 티어별 평가 결과 (decision trace 용)
+}
+
+// TierEvaluation - 개별 티어 평가 결과
+type TierEvaluation struct {
+	Name     string `json:"name"`
+	MinSize  int64  `json:"min_size"`
+	MaxSize  int64  `json:"max_size"`
+	Queue    string `json:"queue"`
+	Executor int    `json:"executor"`
+	InRange  bool   `json:"in_range"` // 크기가 티어 범위에 포함되는지 여부
+	Selected bool   `json:"selected"` // 최종 선택 여부
 }
 
 // ResourceCalculation - 리소스 계산 설정
 type ResourceCalculation struct {
-	Minio string         `json:"minio"`
-	Tiers []ResourceTier `json:"tiers"`
+	Minio       string         `json:"minio"`
+	MinioServer *MinIOConfig   `json:"minio_server,omitempty"` // 이 프로비저닝만 다른 MinIO 서버 사용 (없으면 MINIO_ENDPOINT)
+	Tiers       []ResourceTier `json:"tiers"`
+
+	// Legacy - 레거시 형식(threshold/min_queue/max_queue)에서 변환된 경우 원래 값
+	// 로드 시 Tiers로 변환되며 저장(config migrate, admin API) 시에는 tiers 형식으로만 기록됨
+	Legacy *LegacyResourceCalculation `json:"-"`
 }
 
-// Deprecated: 하위 호환성 유지를 위한 필드 (이전 설정 방식 지원)
+// LegacyResourceCalculation - 이전 resource_calculation 형식 (threshold 기준 2개 큐)
+// 로드 시 migrateLegacyResourceCalculation으로 2단계 티어로 변환됨
+// Deprecated: resource_calculation.tiers 사용 (hynix config migrate로 파일 변환)
 type LegacyResourceCalculation struct {
 	Minio     string `json:"minio"`
 	Threshold int64  `json:"threshold"`
@@ -69,7 +94,13 @@ type GangScheduling struct {
 
 // BuildNumber - 빌드 번호 설정
 type BuildNumber struct {
-	Number string `json:"number"`
+	Number string       `json:"number"`           // 안정 빌드
+	Digest string       `json:"digest,omitempty"` // 안정 빌드 이미지 digest (sha256:..., 지정 시 image를 digest로 고정)
+	Canary *BuildCanary `json:"canary,omitempty"` // 카나리 빌드 (SelectBuild)
+
+	// 빌드 API(SetBuild, canary promote/abort)로 마지막으로 변경한 사용자와 시각
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
 // MinIOMetadata - MinIO 객체 메타데이터
@@ -84,28 +115,48 @@ type MinIOMetadata struct {
 }
 
 // MinIOConfig - MinIO 연결 설정
+// 기본값은 환경 변수(MinIOConfigFromEnv)에서 읽고, 프로비저닝의 resource_calculation.minio_server로 교체할 수 있음
+// 접근 키는 config.json에 기록하지 않고 access_key_env/secret_key_env로 지정한 환경 변수에서 읽음
 type MinIOConfig struct {
-	Endpoint        string
-	AccessKeyID     string
-	SecretAccessKey string
-	UseSSL          bool
+	Endpoint        string `json:"endpoint"` // host:port (예: minio.example.com:9000)
+	AccessKeyID     string `json:"-"`
+	SecretAccessKey string `json:"-"`
+	UseSSL          bool   `json:"use_ssl,omitempty"`
+	Region          string `json:"region,omitempty"`
+	CAFile          string `json:"ca_file,omitempty"`        // 사설 CA 인증서(PEM), 시스템 CA에 추가
+	AccessKeyEnv    string `json:"access_key_env,omitempty"` // 접근 키 환경 변수 (기본 MINIO_ROOT_USER)
+	SecretKeyEnv    string `json:"secret_key_env,omitempty"` // 비밀 키 환경 변수 (기본 MINIO_ROOT_PASSWORD)
 }
 
+// DefaultConfigPath - config.json 기본 경로
+const DefaultConfigPath = "./config/config.json"
+
 // LoadConfig - config.json 로드
+// 설정 출처(ConfigStore, CRDConfigProvider)가 지정되어 있으면 메모리의 설정을 반환하고, 아니면 파일을 직접 읽음
 func LoadConfig() (*Config, error) {
-	configPath := "./config/config.json"
+	if provider := ActiveConfigProvider(); provider != nil {
+		return provider.Config(), nil
+	}
+	return LoadConfigFile(DefaultConfigPath)
+}
 
+// LoadConfigFile - 지정한 경로의 config.json 읽기/파싱
+func LoadConfigFile(configPath string) (*Config, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("config.json 파일 읽기 실패: %w", err)
 	}
+	return parseConfig(data)
+}
 
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
+// parseConfig - config.json 내용 파싱
+func parseConfig(data []byte) (*Config, error) {
+	config, err := decodeConfig(data)
+	if err != nil {
 		return nil, fmt.Errorf("config.json 파싱 실패: %w", err)
 	}
 
-	return &config, nil
+	return config, nil
 }
 
 // FindProvisionConfig - 프로비저닝 ID에 해당하는 설정 찾기
@@ -120,7 +171,7 @@ func FindProvisionConfig(config *Config, provisionID string) (*ConfigSpec, error
 
 // IsProvisionEnabled - 프로비저닝이 활성화되어 있는지 확인
 func IsProvisionEnabled(spec *ConfigSpec) bool {
-	return spec.Enabled == "true"
+	return spec.Enabled
 }
 
 // GetMinioPath - MinIO 경로 생성: {minio_base_path}/{service_id}
@@ -146,47 +197,35 @@ func BuildMinioPath(minioConfigPath, serviceID string) string {
 // minio 경로가 "/"로 끝나면 폴더로 인식하고 모든 오브젝트 크기 합산
 // minio 경로가 "/"로 끝나지 않으면 파일로 인식하고 단일 오브젝트 크기 확인
 // config의 minio 값에 <<service_id>>가 포함된 경우 service_id로 치환
+// server가 nil이면 기본 MinIO 서버(MINIO_ENDPOINT) 사용
+// 크기는 ActiveSizeCache에 캐시되며 noCache=true이면 캐시를 읽지 않고 MinIO에서 조회
 // 반환값: TierSelectionResult, error
-func CalculateQueueWithTiers(minioConfigPath, serviceID string, tiers []ResourceTier) (*TierSelectionResult, error) {
+func CalculateQueueWithTiers(server *MinIOConfig, minioConfigPath, serviceID string, tiers []ResourceTier, noCache bool) (*TierSelectionResult, error) {
 	// MinIO 경로 생성: config의 minio 값에서 <<service_id>>를 service_id로 치환
 	minioPath := BuildMinioPath(minioConfigPath, serviceID)
+	cfg := ResolveMinIOConfig(server)
+	result, err := calculateTiers(ActiveSizeCache(), cfg, minioPath, tiers, noCache)
+	result.MinioURL = cfg.URL()
+	return result, err
+}
 
+// calculateTiers - MinIO 크기 조회 후 티어 선택 (조회 실패 시 첫 번째 티어)
+func calculateTiers(cache *SizeCache, cfg MinIOConfig, minioPath string, tiers []ResourceTier, noCache bool) (*TierSelectionResult, error) {
 	// MinIO 경로가 "/"로 끝나는지 확인 (폴더 vs 파일 구분)
 	var totalSize int64
 	var count int
 	var metadata *MinIOMetadata
+	var cacheResult string
 	var err error
 
 	if strings.HasSuffix(minioPath, "/") {
 		// 폴더: 해당 경로의 모든 오브젝트 크기 합산
-		totalSize, count, err = getMinioFolderSize(minioPath)
+		totalSize, count, cacheResult, err = cache.FolderSize(cfg, minioPath, noCache)
 		if err != nil {
 			// 오류 발생 시 첫 번째 티어를 기본값으로 반환
 			defaultTier := getDefaultTier(tiers)
-			defaultExecutorStr := "1"
-			defaultExecutorInt := 1
-			switch v := defaultTier.Executor.(type) {
-			case string:
-				defaultExecutorStr = v
-				defaultExecutorInt, _ = strconv.Atoi(v)
-				if defaultExecutorInt == 0 {
-					defaultExecutorInt = 1
-				}
-			case int:
-				defaultExecutorInt = v
-				defaultExecutorStr = strconv.Itoa(v)
-			case float64:
-				defaultExecutorInt = int(v)
-				defaultExecutorStr = strconv.Itoa(int(v))
-			}
-			return &TierSelectionResult{
-				Queue:        defaultTier.Queue,
-				Executor:     defaultExecutorStr,
-				ExecutorInt:  defaultExecutorInt,
-				TotalSize:    0,
-				Metadata:     nil,
-				ObjectCount:  0,
-			}, fmt.Errorf("MinIO 폴더 크기 확인 실패: %w (기본값: %s 사용)", err, defaultTier.Queue)
+			result := newTierSelectionResult(defaultTier, minioPath, evaluateDefaultTier(tiers))
+			return result, fmt.Errorf("MinIO 폴더 크기 확인 실패: %w (기본값: %s 사용)", err, defaultTier.Queue)
 		}
 
 		// 폴더 메타데이터 생성
@@ -196,66 +235,34 @@ func CalculateQueueWithTiers(minioConfigPath, serviceID string, tiers []Resource
 		}
 	} else {
 		// 파일: 단일 오브젝트 메타데이터 확인
-		metadata, err = getMinIOMetadata(minioPath)
+		metadata, cacheResult, err = cache.ObjectMetadata(cfg, minioPath, noCache)
 		if err != nil {
 			// 오류 발생 시 첫 번째 티어를 기본값으로 반환
 			defaultTier := getDefaultTier(tiers)
-			defaultExecutorStr := "1"
-			defaultExecutorInt := 1
-			switch v := defaultTier.Executor.(type) {
-			case string:
-				defaultExecutorStr = v
-				defaultExecutorInt, _ = strconv.Atoi(v)
-				if defaultExecutorInt == 0 {
-					defaultExecutorInt = 1
-				}
-			case int:
-				defaultExecutorInt = v
-				defaultExecutorStr = strconv.Itoa(v)
-			case float64:
-				defaultExecutorInt = int(v)
-				defaultExecutorStr = strconv.Itoa(int(v))
-			}
-			return &TierSelectionResult{
-				Queue:        defaultTier.Queue,
-				Executor:     defaultExecutorStr,
-				ExecutorInt:  defaultExecutorInt,
-				TotalSize:    0,
-				Metadata:     nil,
-				ObjectCount:  0,
-			}, fmt.Errorf("MinIO 파일 크기 확인 실패: %w (기본값: %s 사용)", err, defaultTier.Queue)
+			result := newTierSelectionResult(defaultTier, minioPath, evaluateDefaultTier(tiers))
+			return result, fmt.Errorf("MinIO 파일 크기 확인 실패: %w (기본값: %s 사용)", err, defaultTier.Queue)
 		}
 		totalSize = metadata.Size
 	}
 
 	// 파일 크기에 따라 적절한 티어 선택
-	selectedTier := selectTierBySize(totalSize, tiers)
-
-	// executor 값을 문자열로 변환
-	executorStr := "1" // 기본값
-	switch v := selectedTier.Executor.(type) {
-	case string:
-		executorStr = v
-	case int:
-		executorStr = strconv.Itoa(v)
-	case float64:
-		executorStr = strconv.Itoa(int(v))
-	}
-
-	// executor 문자열을 정수로 변환
-	executorInt, err := strconv.Atoi(executorStr)
-	if err != nil {
-		executorInt = 1 // 기본값
-	}
+	result := newTierSelectionResult(selectTierBySize(totalSize, tiers), minioPath, evaluateTiers(totalSize, tiers))
+	result.TotalSize = totalSize
+	result.Metadata = metadata
+	result.ObjectCount = count
+	result.SizeCache = cacheResult
+	return result, nil
+}
 
+// newTierSelectionResult - 선택된 티어로 결과 생성 (크기/메타데이터는 호출자가 설정)
+func newTierSelectionResult(tier ResourceTier, minioPath string, evaluations []TierEvaluation) *TierSelectionResult {
 	return &TierSelectionResult{
-		Queue:        selectedTier.Queue,
-		Executor:     executorStr,
-		ExecutorInt:  executorInt,
-		TotalSize:    totalSize,
-		Metadata:     metadata,
-		ObjectCount:  count,
-	}, nil
+		Queue:       tier.Queue,
+		Executor:    tier.Executor,
+		MinioPath:   minioPath,
+		TierName:    tier.Name,
+		Evaluations: evaluations,
+	}
 }
 
 // selectTierBySize - 파일 크기에 따라 적절한 티어 선택 (3단계 티어)
@@ -266,18 +273,7 @@ func selectTierBySize(size int64, tiers []ResourceTier) ResourceTier {
 
 	// 티어를 순회하며 적절한 티어 찾기
 	for _, tier := range tiers {
-		// MinSize와 MaxSize 범위 확인
-		inRange := true
-
-		if tier.MinSize > 0 && size < tier.MinSize {
-			inRange = false
-		}
-
-		if tier.MaxSize > 0 && size >= tier.MaxSize {
-			inRange = false
-		}
-
-		if inRange {
+		if tierInRange(size, tier) {
 			return tier
 		}
 	}
@@ -286,6 +282,61 @@ func selectTierBySize(size int64, tiers []ResourceTier) ResourceTier {
 	return tiers[len(tiers)-1]
 }
 
+// tierInRange - 크기가 티어의 MinSize/MaxSize 범위에 포함되는지 확인
+func tierInRange(size int64, tier ResourceTier) bool {
+	if tier.MinSize > 0 && size < tier.MinSize {
+		return false
+	}
+	if tier.MaxSize > 0 && size >= tier.MaxSize {
+		return false
+	}
+	return true
+}
+
+// evaluateTiers - 모든 티어에 대해 범위 포함 여부와 선택 여부 기록 (selectTierBySize와 동일한 규칙)
+func evaluateTiers(size int64, tiers []ResourceTier) []TierEvaluation {
+	evaluations := make([]TierEvaluation, len(tiers))
+	selected := -1
+	for i, tier := range tiers {
+		evaluations[i] = newTierEvaluation(tier)
+		evaluations[i].InRange = tierInRange(size, tier)
+		if selected < 0 && evaluations[i].InRange {
+			selected = i
+		}
+	}
+	// 범위를 벗어난 경우 가장 큰 티어 선택
+	if selected < 0 && len(tiers) > 0 {
+		selected = len(tiers) - 1
+	}
+	if selected >= 0 {
+		evaluations[selected].Selected = true
+	}
+	return evaluations
+}
+
+// evaluateDefaultTier - MinIO 조회 실패 시 첫 번째 티어를 기본값으로 선택한 평가 결과
+func evaluateDefaultTier(tiers []ResourceTier) []TierEvaluation {
+	evaluations := make([]TierEvaluation, len(tiers))
+	for i, tier := range tiers {
+		evaluations[i] = newTierEvaluation(tier)
+	}
+	if len(evaluations) > 0 {
+		evaluations[0].Selected = true
+	}
+	return evaluations
+}
+
+// newTierEvaluation - 티어 설정을 평가 결과 구조체로 변환
+func newTierEvaluation(tier ResourceTier) TierEvaluation {
+	return TierEvaluation{
+		Name:     tier.Name,
+		MinSize:  tier.MinSize,
+		MaxSize:  tier.MaxSize,
+		Queue:    tier.Queue,
+		Executor: tier.Executor,
+	}
+}
+
 // getDefaultQueue - 첫 번째 티어의 큐를 반환 (하위 호환성 유지)
 // Deprecated: getDefaultTier 사용 권장
 func getDefaultQueue(tiers []ResourceTier) string {
@@ -316,7 +367,7 @@ func CalculateQueueWithMetadata(minioConfigPath, serviceID string, threshold int
 	// MinIO 경로가 "/"로 끝나는지 확인 (폴더 vs 파일 구분)
 	if strings.HasSuffix(minioPath, "/") {
 		// 폴더: 해당 경로의 모든 오브젝트 크기 합산
-		totalSize, count, err := getMinioFolderSize(minioPath)
+		totalSize, count, err := getMinioFolderSize(DefaultMinIOConfig(), minioPath)
 		if err != nil {
 			return minQueue, 0, nil, 0, fmt.Errorf("MinIO 폴더 크기 확인 실패: %w (기본값: %s 사용)", err, minQueue)
 		}
@@ -338,7 +389,7 @@ func CalculateQueueWithMetadata(minioConfigPath, serviceID string, threshold int
 		return selectedQueue, totalSize, metadata, count, nil
 	} else {
 		// 파일: 단일 오브젝트 메타데이터 확인
-		metadata, err := getMinIOMetadata(minioPath)
+		metadata, err := getMinIOMetadata(DefaultMinIOConfig(), minioPath, "")
 		if err != nil {
 			return minQueue, 0, nil, 0, fmt.Errorf("MinIO 파일 크기 확인 실패: %w (기본값: %s 사용)", err, minQueue)
 		}
@@ -368,22 +419,12 @@ func CalculateQueue(minioPath string, threshold int64, minQueue, maxQueue string
 }
 
 // getMinIOMetadata - MinIO에서 객체 메타데이터 가져오기 (다운로드 없이 메타데이터만)
-func getMinIOMetadata(minioPath string) (*MinIOMetadata, error) {
-	// MinIO 연결 설정 (환경 변수에서 읽기)
-	accessKey := os.Getenv("MINIO_ROOT_USER")
-	secretKey := os.Getenv("MINIO_ROOT_PASSWORD")
-
-	if accessKey == "" || secretKey == "" {
-		return nil, fmt.Errorf("MinIO 환경 변수 설정 안됨 (MINIO_ROOT_USER, MINIO_ROOT_PASSWORD)")
-	}
-
-	// MinIO 클라이언트 초기화 (로컬호스트)
-	minioClient, err := minio.New("localhost:9000", &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
-		Secure: false,
-	})
+// etag가 있으면 If-None-Match로 조회하고, 객체가 변경되지 않았으면(304) errObjectNotModified 반환
+func getMinIOMetadata(cfg MinIOConfig, minioPath, etag string) (*MinIOMetadata, error) {
+	// 연결 설정별로 재사용하는 MinIO 클라이언트
+	minioClient, err := MinIOClient(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("MinIO 클라이언트 초기화 실패: %w", err)
+		return nil, err
 	}
 
 	// minioPath에서 버킷과 객체 이름 파싱 (예: "bucket/object")
@@ -394,8 +435,15 @@ func getMinIOMetadata(minioPath string) (*MinIOMetadata, error) {
 
 	// 객체 메타데이터만 가져오기 (StatObject - 다운로드 없음)
 	ctx := context.Background()
-	objInfo, err := minioClient.StatObject(ctx, bucket, object, minio.StatObjectOptions{})
+	opts := minio.StatObjectOptions{}
+	if etag != "" {
+		opts.SetMatchETagExcept(etag)
+	}
+	objInfo, err := minioClient.StatObject(ctx, bucket, object, opts)
 	if err != nil {
+		if minio.ToErrorResponse(err).StatusCode == http.StatusNotModified {
+			return nil, errObjectNotModified
+		}
 		return nil, fmt.Errorf("MinIO 객체 메타데이터 조회 실패: %w", err)
 	}
 
@@ -415,7 +463,7 @@ func getMinIOMetadata(minioPath string) (*MinIOMetadata, error) {
 
 // getMinIOObjectSize - MinIO에서 객체 크기만 가져오기 (다운로드 없이 메타데이터만)
 func getMinIOObjectSize(minioPath string) (int64, error) {
-	metadata, err := getMinIOMetadata(minioPath)
+	metadata, err := getMinIOMetadata(DefaultMinIOConfig(), minioPath, "")
 	if err != nil {
 		return 0, err
 	}
@@ -490,22 +538,11 @@ func FormatBytes(bytes int64) string {
 }
 
 // getMinioFolderSize - MinIO 폴더(접두사) 내 모든 오브젝트의 크기 합계 계산
-func getMinioFolderSize(minioPath string) (int64, int, error) {
-	// MinIO 연결 설정 (환경 변수에서 읽기)
-	accessKey := os.Getenv("MINIO_ROOT_USER")
-	secretKey := os.Getenv("MINIO_ROOT_PASSWORD")
-
-	if accessKey == "" || secretKey == "" {
-		return 0, 0, fmt.Errorf("MinIO 환경 변수 설정 안됨 (MINIO_ROOT_USER, MINIO_ROOT_PASSWORD)")
-	}
-
-	// MinIO 클라이언트 초기화 (로컬호스트)
-	minioClient, err := minio.New("localhost:9000", &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
-		Secure: false,
-	})
+func getMinioFolderSize(cfg MinIOConfig, minioPath string) (int64, int, error) {
+	// 연결 설정별로 재사용하는 MinIO 클라이언트
+	minioClient, err := MinIOClient(cfg)
 	if err != nil {
-		return 0, 0, fmt.Errorf("MinIO 클라이언트 초기화 실패: %w", err)
+		return 0, 0, err
 	}
 
 	// minioPath에서 버킷과 접두사 파싱 (예: "bucket/prefix/service_id/")
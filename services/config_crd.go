@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"service-common/metrics"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// provisionConfigGVK - ProvisionConfig CR의 GroupVersionKind (config/crd/provisionconfigs.yaml)
+var provisionConfigGVK = schema.GroupVersionKind{
+	Group:   "hynix.io",
+	Version: "v1alpha1",
+	Kind:    "ProvisionConfig",
+}
+
+const (
+	// ProvisionConfigConditionValid - spec 검증 결과 condition 타입
+	ProvisionConfigConditionValid = "Valid"
+
+	// provisionConfigReasonValidated - 검증 통과 (warning은 message에 표시)
+	provisionConfigReasonValidated = "Validated"
+	// provisionConfigReasonInvalid - spec 디코딩/검증 실패
+	provisionConfigReasonInvalid = "ValidationFailed"
+	// provisionConfigReasonDuplicate - 먼저 생성된 다른 ProvisionConfig가 같은 provision_id를 사용 중
+	provisionConfigReasonDuplicate = "DuplicateProvisionID"
+
+	// provisionConfigSyncTimeout - 시작 시 informer 캐시 동기화 대기 시간
+	provisionConfigSyncTimeout = 30 * time.Second
+
+	// maxConditionMessageLength - metav1.Condition message 최대 길이
+	maxConditionMessageLength = 32768
+)
+
+// CRDConfigProvider - ProvisionConfig 커스텀 리소스를 설정 출처로 사용
+// informer 캐시의 변경 이벤트마다 모든 ProvisionConfig를 다시 검증해 설정을 교체하고,
+// 검증 결과를 각 리소스의 status.conditions(Valid)에 기록
+// 검증에 실패한 리소스는 설정에서 제외됨 (다른 프로비저닝에는 영향 없음)
+type CRDConfigProvider struct {
+	namespace string         // 비어 있으면 모든 네임스페이스
+	history   *ConfigHistory // nil이면 revision을 기록하지 않음
+	cache     cache.Cache
+	config    atomic.Pointer[Config]
+	checksum  string        // 마지막으로 적용한 설정의 sha256 (변경 시에만 로그)
+	trigger   chan struct{} // 재동기화 요청 (이벤트가 몰리면 하나로 합쳐짐)
+}
+
+// provisionConfigStatus - ProvisionConfig status
+type provisionConfigStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// StartCRDConfigProvider - ProvisionConfig informer를 시작하고 LoadConfig의 설정 출처로 지정
+// 캐시가 동기화되고 첫 검증이 끝난 뒤 반환하며, ctx가 취소되면 감시를 중단
+func StartCRDConfigProvider(ctx context.Context, namespace string, history *ConfigHistory) (*CRDConfigProvider, error) {
+	// status 업데이트용 클라이언트
+	if err := initK8sClient(); err != nil {
+		return nil, err
+	}
+
+	cfg, err := loadRestConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := cache.Options{}
+	if namespace != "" {
+		opts.DefaultNamespaces = map[string]cache.Config{namespace: {}}
+	}
+	informerCache, err := cache.New(cfg, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ProvisionConfig 캐시 생성 실패: %w", err)
+	}
+
+	p := &CRDConfigProvider{
+		namespace: namespace,
+		history:   history,
+		cache:     informerCache,
+		trigger:   make(chan struct{}, 1),
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(provisionConfigGVK)
+	informer, err := informerCache.GetInformer(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("ProvisionConfig informer 생성 실패 (CRD 설치 여부 확인): %w", err)
+	}
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { p.notify() },
+		UpdateFunc: func(interface{}, interface{}) { p.notify() },
+		DeleteFunc: func(interface{}) { p.notify() },
+	}); err != nil {
+		return nil, fmt.Errorf("ProvisionConfig 이벤트 핸들러 등록 실패: %w", err)
+	}
+
+	go func() {
+		if err := informerCache.Start(ctx); err != nil {
+			log.Printf("ProvisionConfig 캐시 중단: %v", err)
+		}
+	}()
+
+	syncCtx, cancel := context.WithTimeout(ctx, provisionConfigSyncTimeout)
+	defer cancel()
+	if !informerCache.WaitForCacheSync(syncCtx) {
+		return nil, fmt.Errorf("ProvisionConfig 캐시 동기화 실패 (%s 초과)", provisionConfigSyncTimeout)
+	}
+
+	if err := p.sync(ctx); err != nil {
+		return nil, err
+	}
+	SetConfigProvider(p)
+
+	go p.run(ctx)
+	return p, nil
+}
+
+// Config - 현재 적용 중인 설정 (호출자는 수정하면 안 됨)
+func (p *CRDConfigProvider) Config() *Config {
+	return p.config.Load()
+}
+
+// Source - 설정 출처 (ConfigProvider)
+func (p *CRDConfigProvider) Source() string {
+	return ConfigSourceCRD
+}
+
+// notify - 재동기화 요청 (이미 대기 중인 요청이 있으면 무시)
+func (p *CRDConfigProvider) notify() {
+	select {
+	case p.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// run - 재동기화 요청 처리 (동기화는 한 번에 하나씩)
+func (p *CRDConfigProvider) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.trigger:
+			if err := p.sync(ctx); err != nil {
+				metrics.ConfigReloads.WithLabelValues("invalid").Inc()
+				log.Printf("ProvisionConfig 동기화 실패, 이전 설정 유지: %v", err)
+			}
+		}
+	}
+}
+
+// sync - 캐시의 모든 ProvisionConfig를 검증해 설정을 교체하고 status 갱신
+// provision_id가 중복되면 먼저 생성된 리소스를 사용
+func (p *CRDConfigProvider) sync(ctx context.Context) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(provisionConfigGVK.GroupVersion().WithKind(provisionConfigGVK.Kind + "List"))
+	var opts []client.ListOption
+	if p.namespace != "" {
+		opts = append(opts, client.InNamespace(p.namespace))
+	}
+	if err := p.cache.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("ProvisionConfig 목록 조회 실패: %w", err)
+	}
+
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool {
+		ti, tj := items[i].GetCreationTimestamp(), items[j].GetCreationTimestamp()
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return provisionConfigKey(&items[i]) < provisionConfigKey(&items[j])
+	})
+
+	config := &Config{ConfigSpecs: []ConfigSpec{}}
+	owners := make(map[string]string, len(items))
+	invalid := 0
+	for i := range items {
+		obj := &items[i]
+		spec, issues := decodeProvisionConfig(obj)
+		reason := provisionConfigReasonInvalid
+
+		if !issues.HasErrors() {
+			if owner, ok := owners[spec.ProvisionID]; ok {
+				issues = append(issues, ConfigIssue{Path: "spec.provision_id", Severity: SeverityError,
+					Message: fmt.Sprintf("provision_id %s는 %s에서 이미 사용 중입니다", spec.ProvisionID, owner)})
+				reason = provisionConfigReasonDuplicate
+			}
+		}
+
+		if issues.HasErrors() {
+			invalid++
+		} else {
+			owners[spec.ProvisionID] = provisionConfigKey(obj)
+			config.ConfigSpecs = append(config.ConfigSpecs, *spec)
+			reason = provisionConfigReasonValidated
+		}
+
+		if err := p.updateStatus(ctx, obj, issues, reason); err != nil {
+			log.Printf("ProvisionConfig %s status 갱신 실패: %v", provisionConfigKey(obj), err)
+		}
+	}
+
+	// status 갱신으로 인한 재동기화마다 로그/revision을 남기지 않도록 설정이 바뀐 경우에만 기록
+	data, err := marshalConfig(config)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if checksum != p.checksum {
+		p.checksum = checksum
+		if p.history != nil {
+			if revision, err := p.history.Record(data, checksum, ConfigChange{Source: ConfigChangeCRD}); err != nil {
+				log.Printf("ProvisionConfig revision 기록 실패: %v", err)
+			} else {
+				config.Revision = revision.Revision
+			}
+		}
+		log.Printf("ProvisionConfig 설정 적용 (checksum: %.12s, revision: %d, valid: %d, invalid: %d)", checksum, config.Revision, len(config.ConfigSpecs), invalid)
+	} else if previous := p.config.Load(); previous != nil {
+		config.Revision = previous.Revision
+	}
+
+	p.config.Store(config)
+	metrics.ProvisionConfigObjects.WithLabelValues("valid").Set(float64(len(config.ConfigSpecs)))
+	metrics.ProvisionConfigObjects.WithLabelValues("invalid").Set(float64(invalid))
+	metrics.ConfigReloads.WithLabelValues("success").Inc()
+	metrics.ConfigLastReloadSuccess.SetToCurrentTime()
+	return nil
+}
+
+// decodeProvisionConfig - ProvisionConfig spec을 ConfigSpec으로 디코딩하고 검증
+// 문제 경로는 리소스 기준 (예: spec.resource_calculation.tiers[1].max_size)
+func decodeProvisionConfig(obj *unstructured.Unstructured) (*ConfigSpec, ConfigIssues) {
+	data, err := json.Marshal(obj.Object["spec"])
+	if err != nil {
+		return nil, ConfigIssues{{Path: "spec", Severity: SeverityError, Message: err.Error()}}
+	}
+
+	var spec ConfigSpec
+	if err := decodeStrict(data, &spec); err != nil {
+		return nil, ConfigIssues{jsonErrorIssue(withDecodePath("spec", err))}
+	}
+
+	issues := ValidateConfig(&Config{ConfigSpecs: []ConfigSpec{spec}}, TemplateDir())
+	for i := range issues {
+		issues[i].Path = "spec" + strings.TrimPrefix(issues[i].Path, "$.config_specs[0]")
+	}
+	return &spec, issues
+}
+
+// updateStatus - 검증 결과를 Valid condition으로 기록 (바뀐 경우에만 status 패치)
+func (p *CRDConfigProvider) updateStatus(ctx context.Context, obj *unstructured.Unstructured, issues ConfigIssues, reason string) error {
+	condition := metav1.Condition{
+		Type:               ProvisionConfigConditionValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            "spec 검증 통과",
+		ObservedGeneration: obj.GetGeneration(),
+	}
+	if issues.HasErrors() {
+		condition.Status = metav1.ConditionFalse
+	}
+	if len(issues) > 0 {
+		messages := make([]string, len(issues))
+		for i, issue := range issues {
+			messages[i] = issue.String()
+		}
+		condition.Message = strings.Join(messages, "; ")
+		if len(condition.Message) > maxConditionMessageLength {
+			condition.Message = condition.Message[:maxConditionMessageLength-3] + "..."
+		}
+	}
+
+	var status provisionConfigStatus
+	if raw, ok := obj.Object["status"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &status); err != nil {
+			return fmt.Errorf("status 변환 실패: %w", err)
+		}
+	}
+	if !meta.SetStatusCondition(&status.Conditions, condition) {
+		return nil
+	}
+
+	statusMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return fmt.Errorf("status 변환 실패: %w", err)
+	}
+	updated := obj.DeepCopy()
+	updated.Object["status"] = statusMap
+	return k8sClient.Status().Patch(ctx, updated, client.MergeFrom(obj))
+}
+
+// provisionConfigKey - namespace/name
+func provisionConfigKey(obj *unstructured.Unstructured) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
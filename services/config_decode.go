@@ -0,0 +1,288 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	boolType = reflect.TypeOf(false)
+	intType  = reflect.TypeOf(0)
+)
+
+// ConfigDecodeError - config.json 디코딩 실패 위치 (path는 JSON 경로, 예: $.config_specs[1].enabled)
+type ConfigDecodeError struct {
+	Path string
+	Err  error
+}
+
+func (e *ConfigDecodeError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *ConfigDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeConfig - config.json 엄격 디코딩 (알 수 없는 필드는 오류)
+// 어느 프로비저닝에서 실패했는지 알 수 있도록 config_specs 항목을 하나씩 디코딩
+func decodeConfig(data []byte) (*Config, error) {
+	var raw struct {
+		ConfigSpecs []json.RawMessage `json:"config_specs"`
+	}
+	if err := decodeStrict(data, &raw); err != nil {
+		return nil, withDecodePath("$", err)
+	}
+
+	config := &Config{ConfigSpecs: make([]ConfigSpec, len(raw.ConfigSpecs))}
+	for i, specData := range raw.ConfigSpecs {
+		if err := decodeStrict(specData, &config.ConfigSpecs[i]); err != nil {
+			return nil, withDecodePath(fmt.Sprintf("$.config_specs[%d]", i), err)
+		}
+	}
+	return config, nil
+}
+
+// decodeStrict - 알 수 없는 필드와 뒤따르는 데이터를 허용하지 않는 JSON 디코딩
+func decodeStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("JSON 값 뒤에 불필요한 데이터가 있습니다")
+	}
+	return nil
+}
+
+// withDecodePath - 디코딩 오류에 JSON 경로 접두사 추가
+// 중첩된 UnmarshalJSON에서 반환된 경로와 타입 오류의 필드 이름을 이어 붙임
+func withDecodePath(prefix string, err error) error {
+	var decodeErr *ConfigDecodeError
+	if errors.As(err, &decodeErr) {
+		return &ConfigDecodeError{Path: joinDecodePath(prefix, decodeErr.Path), Err: decodeErr.Err}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return &ConfigDecodeError{Path: joinDecodePath(prefix, typeErr.Field), Err: err}
+	}
+	return &ConfigDecodeError{Path: prefix, Err: err}
+}
+
+// joinDecodePath - "a" + "b" → "a.b", "a" + "[0].b" → "a[0].b"
+func joinDecodePath(prefix, path string) string {
+	if strings.HasPrefix(path, "[") {
+		return prefix + path
+	}
+	return prefix + "." + path
+}
+
+// UnmarshalJSON - enabled의 하위 호환 디코딩 ("true"/"false" 문자열 허용)
+// 중첩된 필드도 알 수 없는 필드를 허용하지 않음
+func (s *ConfigSpec) UnmarshalJSON(data []byte) error {
+	type configSpec ConfigSpec
+	aux := struct {
+		*configSpec
+		Enabled             json.RawMessage   `json:"enabled"`
+		ResourceCalculation json.RawMessage   `json:"resource_calculation"`
+		Overrides           []json.RawMessage `json:"overrides"`
+	}{configSpec: (*configSpec)(s)}
+
+	if err := decodeStrict(data, &aux); err != nil {
+		return err
+	}
+
+	enabled, err := decodeLegacyBool(aux.Enabled)
+	if err != nil {
+		return withDecodePath("enabled", err)
+	}
+	s.Enabled = enabled
+
+	if len(aux.ResourceCalculation) > 0 {
+		if err := decodeStrict(aux.ResourceCalculation, &s.ResourceCalculation); err != nil {
+			return withDecodePath("resource_calculation", err)
+		}
+	}
+
+	s.Overrides = nil
+	if aux.Overrides != nil {
+		s.Overrides = make([]ProvisionOverride, len(aux.Overrides))
+	}
+	for i, overrideData := range aux.Overrides {
+		if err := decodeStrict(overrideData, &s.Overrides[i]); err != nil {
+			return withDecodePath(fmt.Sprintf("overrides[%d]", i), err)
+		}
+	}
+
+	// 레거시 형식은 gang_scheduling.executor가 executor 개수였으므로 spec 단위로 변환
+	if legacy := s.ResourceCalculation.Legacy; legacy != nil {
+		s.ResourceCalculation.Tiers = legacyTiers(legacy, s.GangScheduling.Executor)
+	}
+	return nil
+}
+
+// UnmarshalJSON - 오류 위치에 티어 인덱스가 포함되도록 tiers를 하나씩 디코딩
+func (o *ProvisionOverride) UnmarshalJSON(data []byte) error {
+	type provisionOverride ProvisionOverride
+	aux := struct {
+		*provisionOverride
+		Tiers []json.RawMessage `json:"tiers"`
+	}{provisionOverride: (*provisionOverride)(o)}
+
+	if err := decodeStrict(data, &aux); err != nil {
+		return err
+	}
+
+	tiers, err := decodeTiers(aux.Tiers)
+	if err != nil {
+		return err
+	}
+	o.Tiers = tiers
+	return nil
+}
+
+// UnmarshalJSON - 오류 위치에 티어 인덱스가 포함되도록 tiers를 하나씩 디코딩
+// 레거시 형식(threshold/min_queue/max_queue)이면 Legacy에 원래 값을 보관 (티어 변환은 ConfigSpec에서)
+func (rc *ResourceCalculation) UnmarshalJSON(data []byte) error {
+	type resourceCalculation ResourceCalculation
+	aux := struct {
+		*resourceCalculation
+		Tiers     []json.RawMessage `json:"tiers"`
+		Threshold *int64            `json:"threshold"`
+		MinQueue  *string           `json:"min_queue"`
+		MaxQueue  *string           `json:"max_queue"`
+	}{resourceCalculation: (*resourceCalculation)(rc)}
+
+	if err := decodeStrict(data, &aux); err != nil {
+		return err
+	}
+
+	rc.Legacy = nil
+	if aux.Threshold != nil || aux.MinQueue != nil || aux.MaxQueue != nil {
+		if aux.Tiers != nil {
+			return &ConfigDecodeError{Path: "tiers", Err: errors.New("tiers와 레거시 threshold/min_queue/max_queue를 함께 사용할 수 없습니다")}
+		}
+		legacy := &LegacyResourceCalculation{Minio: rc.Minio}
+		if aux.Threshold != nil {
+			legacy.Threshold = *aux.Threshold
+		}
+		if legacy.Threshold <= 0 {
+			return &ConfigDecodeError{Path: "threshold", Err: fmt.Errorf("threshold는 0보다 커야 합니다 (현재: %d)", legacy.Threshold)}
+		}
+		if aux.MinQueue != nil {
+			legacy.MinQueue = *aux.MinQueue
+		}
+		if aux.MaxQueue != nil {
+			legacy.MaxQueue = *aux.MaxQueue
+		}
+		rc.Legacy = legacy
+		rc.Tiers = nil
+		return nil
+	}
+
+	tiers, err := decodeTiers(aux.Tiers)
+	if err != nil {
+		return err
+	}
+	rc.Tiers = tiers
+	return nil
+}
+
+// decodeTiers - 티어를 하나씩 디코딩 (생략하면 nil)
+func decodeTiers(raw []json.RawMessage) ([]ResourceTier, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	tiers := make([]ResourceTier, len(raw))
+	for i, tierData := range raw {
+		if err := decodeStrict(tierData, &tiers[i]); err != nil {
+			return nil, withDecodePath(fmt.Sprintf("tiers[%d]", i), err)
+		}
+	}
+	return tiers, nil
+}
+
+// UnmarshalJSON - executor의 하위 호환 디코딩 ("2" 같은 숫자 문자열 허용)
+func (t *ResourceTier) UnmarshalJSON(data []byte) error {
+	type resourceTier ResourceTier
+	aux := struct {
+		*resourceTier
+		Executor json.RawMessage `json:"executor"`
+	}{resourceTier: (*resourceTier)(t)}
+
+	if err := decodeStrict(data, &aux); err != nil {
+		return err
+	}
+
+	executor, err := decodeLegacyInt(aux.Executor)
+	if err != nil {
+		return withDecodePath("executor", err)
+	}
+	t.Executor = executor
+	return nil
+}
+
+// legacyTiers - 레거시 resource_calculation을 2단계 티어로 변환
+// threshold 미만은 min_queue, 이상은 max_queue (이전 CalculateQueueWithMetadata와 같은 경계)
+// executor 개수는 이전과 같이 gang_scheduling.executor (없거나 잘못된 값이면 1)
+func legacyTiers(legacy *LegacyResourceCalculation, gangExecutor string) []ResourceTier {
+	executor, err := strconv.Atoi(gangExecutor)
+	if err != nil || executor < 1 {
+		executor = 1
+	}
+	return []ResourceTier{
+		{Name: "small", MaxSize: legacy.Threshold, Queue: legacy.MinQueue, Executor: executor},
+		{Name: "large", MinSize: legacy.Threshold, Queue: legacy.MaxQueue, Executor: executor},
+	}
+}
+
+// decodeLegacyBool - true/false 또는 "true"/"false" 디코딩 (없으면 false)
+func decodeLegacyBool(data json.RawMessage) (bool, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return false, nil
+	}
+
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		return value, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		switch str {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, &json.UnmarshalTypeError{Value: string(data), Type: boolType}
+}
+
+// decodeLegacyInt - 정수 또는 숫자 문자열 디코딩 (없으면 0)
+func decodeLegacyInt(data json.RawMessage) (int, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return 0, nil
+	}
+
+	var value int
+	if err := json.Unmarshal(data, &value); err == nil {
+		return value, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		if value, err := strconv.Atoi(str); err == nil {
+			return value, nil
+		}
+	}
+	return 0, &json.UnmarshalTypeError{Value: string(data), Type: intType}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeConfigLegacyForms(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         string
+		wantEnabled  bool
+		wantExecutor int
+	}{
+		{
+			name:         "typed values",
+			spec:         `{"enabled": true, "resource_calculation": {"tiers": [{"queue": "q", "executor": 2}]}}`,
+			wantEnabled:  true,
+			wantExecutor: 2,
+		},
+		{
+			name:         "string values",
+			spec:         `{"enabled": "true", "resource_calculation": {"tiers": [{"queue": "q", "executor": "3"}]}}`,
+			wantEnabled:  true,
+			wantExecutor: 3,
+		},
+		{
+			name:         "string false",
+			spec:         `{"enabled": "false", "resource_calculation": {"tiers": [{"queue": "q", "executor": 1}]}}`,
+			wantExecutor: 1,
+		},
+		{
+			name:         "omitted and null",
+			spec:         `{"enabled": null, "resource_calculation": {"tiers": [{"queue": "q"}]}}`,
+			wantExecutor: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := decodeConfig([]byte(`{"config_specs": [` + tt.spec + `]}`))
+			if err != nil {
+				t.Fatalf("decodeConfig error = %v", err)
+			}
+			spec := config.ConfigSpecs[0]
+			if spec.Enabled != tt.wantEnabled {
+				t.Errorf("enabled = %v, want %v", spec.Enabled, tt.wantEnabled)
+			}
+			if got := spec.ResourceCalculation.Tiers[0].Executor; got != tt.wantExecutor {
+				t.Errorf("executor = %d, want %d", got, tt.wantExecutor)
+			}
+		})
+	}
+}
+
+func TestDecodeConfigErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantPath string
+	}{
+		{
+			name:     "invalid enabled string",
+			data:     `{"config_specs": [{}, {"enabled": "yes"}]}`,
+			wantPath: "$.config_specs[1].enabled",
+		},
+		{
+			name:     "invalid executor string",
+			data:     `{"config_specs": [{"resource_calculation": {"tiers": [{"queue": "q"}, {"queue": "q", "executor": "two"}]}}]}`,
+			wantPath: "$.config_specs[0].resource_calculation.tiers[1].executor",
+		},
+		{
+			name:     "unknown spec field",
+			data:     `{"config_specs": [{"enabeld": true}]}`,
+			wantPath: "$.config_specs[0]",
+		},
+		{
+			name:     "unknown top-level field",
+			data:     `{"config_specs": [], "extra": 1}`,
+			wantPath: "$",
+		},
+		{
+			name:     "wrong type",
+			data:     `{"config_specs": [{"provision_id": 1}]}`,
+			wantPath: "$.config_specs[0].provision_id",
+		},
+		{
+			name:     "trailing data",
+			data:     `{"config_specs": []} {}`,
+			wantPath: "$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeConfig([]byte(tt.data))
+			var decodeErr *ConfigDecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("decodeConfig error = %v, want ConfigDecodeError", err)
+			}
+			if decodeErr.Path != tt.wantPath {
+				t.Errorf("path = %s, want %s (%v)", decodeErr.Path, tt.wantPath, decodeErr.Err)
+			}
+		})
+	}
+}
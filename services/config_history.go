@@ -0,0 +1,253 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	// DefaultConfigHistoryDir - 설정 revision 저장 디렉토리 기본값
+	DefaultConfigHistoryDir = "./config/history"
+	// DefaultConfigHistoryLimit - 보관할 최대 revision 수 (오래된 것부터 삭제)
+	DefaultConfigHistoryLimit = 100
+)
+
+const (
+	// ConfigChangeFile - config.json 파일 변경 감지 (Jenkins 커밋, 수동 수정)
+	ConfigChangeFile = "file"
+	// ConfigChangeAdmin - admin API로 프로비저닝 변경
+	ConfigChangeAdmin = "admin"
+	// ConfigChangeRollback - 이전 revision으로 롤백
+	ConfigChangeRollback = "rollback"
+	// ConfigChangeCRD - ProvisionConfig 리소스 변경
+	ConfigChangeCRD = "crd"
+)
+
+// ErrRevisionNotFound - 요청한 revision이 없음 (보관 개수를 넘어 삭제되었을 수 있음)
+var ErrRevisionNotFound = errors.New("설정 revision을 찾을 수 없습니다")
+
+// ConfigChange - 설정 변경 출처 (revision 기록용)
+type ConfigChange struct {
+	Source  string // file, admin, rollback, crd
+	Author  string // admin API 사용자 이름
+	Message string
+}
+
+// ConfigRevision - 서비스가 적용한 설정 revision
+type ConfigRevision struct {
+	Revision int64     `json:"revision"` // 1부터 단조 증가 (재시작 후에도 이어짐)
+	Checksum string    `json:"checksum"` // 설정 내용의 sha256
+	LoadedAt time.Time `json:"loaded_at"`
+	Source   string    `json:"source"`
+	Author   string    `json:"author,omitempty"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// configRevisionRecord - revision 파일 내용 (메타데이터 + 설정 원문)
+type configRevisionRecord struct {
+	ConfigRevision
+	Content string `json:"content"`
+}
+
+// ConfigDiff - 두 revision의 unified diff
+type ConfigDiff struct {
+	From int64  `json:"from"`
+	To   int64  `json:"to"`
+	Diff string `json:"diff"` // 내용이 같으면 빈 문자열
+}
+
+// ConfigHistory - 적용된 설정 revision을 디렉토리에 보관 (revision마다 파일 하나, 000012.json)
+type ConfigHistory struct {
+	dir   string
+	limit int
+
+	mu        sync.Mutex
+	revisions []ConfigRevision // revision 오름차순
+}
+
+// OpenConfigHistory - revision 디렉토리를 열고 기존 revision 메타데이터 로드
+func OpenConfigHistory(dir string, limit int) (*ConfigHistory, error) {
+	if limit < 1 {
+		limit = DefaultConfigHistoryLimit
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("설정 revision 디렉토리 생성 실패: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("설정 revision 디렉토리 읽기 실패: %w", err)
+	}
+
+	h := &ConfigHistory{dir: dir, limit: limit}
+	for _, entry := range entries {
+		if entry.IsDir() || !isRevisionFileName(entry.Name()) {
+			continue
+		}
+		record, err := readRevisionFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			log.Printf("설정 revision 파일 무시 (%s): %v", entry.Name(), err)
+			continue
+		}
+		h.revisions = append(h.revisions, record.ConfigRevision)
+	}
+	sort.Slice(h.revisions, func(i, j int) bool { return h.revisions[i].Revision < h.revisions[j].Revision })
+	return h, nil
+}
+
+// Dir - revision 디렉토리
+func (h *ConfigHistory) Dir() string {
+	return h.dir
+}
+
+// Record - 적용된 설정 내용을 새 revision으로 기록
+// 마지막 revision과 내용이 같으면(재시작, 같은 내용으로 되돌림) 새 revision을 만들지 않고 마지막 revision 반환
+func (h *ConfigHistory) Record(data []byte, checksum string, change ConfigChange) (ConfigRevision, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if n := len(h.revisions); n > 0 && h.revisions[n-1].Checksum == checksum {
+		return h.revisions[n-1], nil
+	}
+
+	var next int64 = 1
+	if n := len(h.revisions); n > 0 {
+		next = h.revisions[n-1].Revision + 1
+	}
+	record := configRevisionRecord{
+		ConfigRevision: ConfigRevision{
+			Revision: next,
+			Checksum: checksum,
+			LoadedAt: time.Now(),
+			Source:   change.Source,
+			Author:   change.Author,
+			Message:  change.Message,
+		},
+		Content: string(data),
+	}
+
+	encoded, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return ConfigRevision{}, fmt.Errorf("설정 revision 직렬화 실패: %w", err)
+	}
+	if err := writeFileAtomic(h.path(next), encoded); err != nil {
+		return ConfigRevision{}, fmt.Errorf("설정 revision %d 저장 실패: %w", next, err)
+	}
+	h.revisions = append(h.revisions, record.ConfigRevision)
+
+	// 보관 개수를 넘은 오래된 revision 삭제
+	for len(h.revisions) > h.limit {
+		if err := os.Remove(h.path(h.revisions[0].Revision)); err != nil && !os.IsNotExist(err) {
+			log.Printf("설정 revision %d 삭제 실패: %v", h.revisions[0].Revision, err)
+		}
+		h.revisions = h.revisions[1:]
+	}
+	return record.ConfigRevision, nil
+}
+
+// List - 보관 중인 revision 목록 (최신순)
+func (h *ConfigHistory) List() []ConfigRevision {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make([]ConfigRevision, len(h.revisions))
+	for i, revision := range h.revisions {
+		result[len(h.revisions)-1-i] = revision
+	}
+	return result
+}
+
+// Latest - 마지막으로 기록된 revision (없으면 false)
+func (h *ConfigHistory) Latest() (ConfigRevision, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.revisions) == 0 {
+		return ConfigRevision{}, false
+	}
+	return h.revisions[len(h.revisions)-1], true
+}
+
+// Get - revision 메타데이터와 설정 원문 조회
+func (h *ConfigHistory) Get(revision int64) (*ConfigRevision, []byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.hasLocked(revision) {
+		return nil, nil, ErrRevisionNotFound
+	}
+	record, err := readRevisionFile(h.path(revision))
+	if err != nil {
+		return nil, nil, fmt.Errorf("설정 revision %d 읽기 실패: %w", revision, err)
+	}
+	return &record.ConfigRevision, []byte(record.Content), nil
+}
+
+// Diff - from → to revision의 unified diff
+func (h *ConfigHistory) Diff(from, to int64) (*ConfigDiff, error) {
+	_, fromData, err := h.Get(from)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d: %w", from, err)
+	}
+	_, toData, err := h.Get(to)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d: %w", to, err)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(fromData)),
+		B:        difflib.SplitLines(string(toData)),
+		FromFile: fmt.Sprintf("revision %d", from),
+		ToFile:   fmt.Sprintf("revision %d", to),
+		Context:  3,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("diff 생성 실패: %w", err)
+	}
+	return &ConfigDiff{From: from, To: to, Diff: diff}, nil
+}
+
+// hasLocked - 보관 중인 revision인지 확인
+func (h *ConfigHistory) hasLocked(revision int64) bool {
+	i := sort.Search(len(h.revisions), func(i int) bool { return h.revisions[i].Revision >= revision })
+	return i < len(h.revisions) && h.revisions[i].Revision == revision
+}
+
+// path - revision 파일 경로 (이름순 정렬이 revision순과 같도록 0으로 채움)
+func (h *ConfigHistory) path(revision int64) string {
+	return filepath.Join(h.dir, fmt.Sprintf("%06d.json", revision))
+}
+
+// isRevisionFileName - 000012.json 형식인지 확인
+func isRevisionFileName(name string) bool {
+	base, ok := strings.CutSuffix(name, ".json")
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseInt(base, 10, 64)
+	return err == nil
+}
+
+// readRevisionFile - revision 파일 읽기
+func readRevisionFile(path string) (*configRevisionRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var record configRevisionRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
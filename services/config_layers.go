@@ -0,0 +1,247 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// configEnvPattern - 설정 값 안의 ${NAME} 또는 ${NAME:-기본값}
+var configEnvPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// ConfigLayerError - 어느 설정 파일에서 읽기/병합이 실패했는지 포함한 오류
+type ConfigLayerError struct {
+	File string
+	Err  error
+}
+
+func (e *ConfigLayerError) Error() string {
+	return fmt.Sprintf("%s: %v", e.File, e.Err)
+}
+
+func (e *ConfigLayerError) Unwrap() error {
+	return e.Err
+}
+
+// SplitConfigFiles - 쉼표로 구분된 설정 파일 목록 (CONFIG_FILES="config/config.json,config/config.prod.json")
+func SplitConfigFiles(value string) []string {
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// ReadConfigFiles - 설정 파일들을 순서대로 병합하고 값 안의 ${ENV}를 치환한 config.json 내용 반환
+// overlay 파일이 있거나 ${ENV} 치환으로 값이 바뀐 경우에만 composed=true
+// 파일이 하나이고 치환된 값이 없으면 파일 내용을 그대로 반환 (composed=false, 값에 "${"가 있어도 변수 형식이 아니면 그대로)
+// composed=true인 내용은 디스크의 어느 파일과도 같지 않으므로 admin API로 기록할 수 없음
+//
+// 병합 규칙 (뒤 파일이 우선):
+//   - 객체는 키별로 재귀 병합, 값이 null이면 키 삭제
+//   - config_specs는 provision_id가 같은 항목끼리 병합하고, 없는 provision_id는 뒤에 추가
+//   - 그 외 배열(tiers 등)과 값은 통째로 교체
+func ReadConfigFiles(paths []string) ([]byte, bool, error) {
+	if len(paths) == 0 {
+		return nil, false, fmt.Errorf("설정 파일이 지정되지 않았습니다")
+	}
+
+	var merged interface{}
+	var single []byte
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, false, &ConfigLayerError{File: path, Err: fmt.Errorf("파일 읽기 실패: %w", err)}
+		}
+		if len(paths) == 1 {
+			if !configEnvPattern.Match(data) {
+				return data, false, nil
+			}
+			single = data
+		}
+
+		layer, err := decodeConfigLayer(data)
+		if err != nil {
+			return nil, false, &ConfigLayerError{File: path, Err: withDecodePath("$", err)}
+		}
+		if i == 0 {
+			merged = layer
+			continue
+		}
+		if merged, err = mergeConfigValue(merged, layer, "$"); err != nil {
+			return nil, false, &ConfigLayerError{File: path, Err: err}
+		}
+	}
+
+	expanded, replaced, err := expandConfigEnv(merged, "$")
+	if err != nil {
+		return nil, false, err
+	}
+	if single != nil && !replaced {
+		// 변수 형식의 문자열이 있었지만 값이 바뀌지 않음 (환경 변수 값이 원래 문자열과 같은 경우 등)
+		return single, false, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(expanded); err != nil {
+		return nil, false, fmt.Errorf("병합된 설정 직렬화 실패: %w", err)
+	}
+	return buf.Bytes(), true, nil
+}
+
+// decodeConfigLayer - 설정 파일을 범용 JSON 값으로 디코딩 (숫자는 원래 표기 유지)
+func decodeConfigLayer(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var layer interface{}
+	if err := decoder.Decode(&layer); err != nil {
+		return nil, err
+	}
+	if _, ok := layer.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("최상위 값은 객체여야 합니다")
+	}
+	return layer, nil
+}
+
+// mergeConfigValue - overlay를 base 위에 병합 (JSON Merge Patch + config_specs provision_id 병합)
+func mergeConfigValue(base, overlay interface{}, path string) (interface{}, error) {
+	overlayMap, ok := overlay.(map[string]interface{})
+	if !ok {
+		return overlay, nil
+	}
+	baseMap, ok := base.(map[string]interface{})
+	if !ok {
+		baseMap = map[string]interface{}{}
+	}
+
+	keys := make([]string, 0, len(overlayMap))
+	for key := range overlayMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := overlayMap[key]
+		keyPath := path + "." + key
+		if value == nil {
+			delete(baseMap, key)
+			continue
+		}
+
+		var err error
+		if keyPath == "$.config_specs" {
+			baseMap[key], err = mergeConfigSpecs(baseMap[key], value, keyPath)
+		} else {
+			baseMap[key], err = mergeConfigValue(baseMap[key], value, keyPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return baseMap, nil
+}
+
+// mergeConfigSpecs - config_specs 항목을 provision_id 기준으로 병합
+func mergeConfigSpecs(base, overlay interface{}, path string) (interface{}, error) {
+	overlaySpecs, ok := overlay.([]interface{})
+	if !ok {
+		return nil, &ConfigDecodeError{Path: path, Err: fmt.Errorf("배열이어야 합니다")}
+	}
+	baseSpecs, _ := base.([]interface{})
+
+	index := make(map[string]int, len(baseSpecs))
+	for i, spec := range baseSpecs {
+		if id, ok := configSpecID(spec); ok {
+			index[id] = i
+		}
+	}
+
+	for i, spec := range overlaySpecs {
+		specPath := fmt.Sprintf("%s[%d]", path, i)
+		id, ok := configSpecID(spec)
+		if !ok {
+			return nil, &ConfigDecodeError{Path: specPath + ".provision_id", Err: fmt.Errorf("overlay 항목에는 provision_id가 필요합니다")}
+		}
+
+		existing, found := index[id]
+		if !found {
+			index[id] = len(baseSpecs)
+			baseSpecs = append(baseSpecs, spec)
+			continue
+		}
+		merged, err := mergeConfigValue(baseSpecs[existing], spec, specPath)
+		if err != nil {
+			return nil, err
+		}
+		baseSpecs[existing] = merged
+	}
+	return baseSpecs, nil
+}
+
+// configSpecID - config_specs 항목의 provision_id
+func configSpecID(spec interface{}) (string, bool) {
+	specMap, ok := spec.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	id, ok := specMap["provision_id"].(string)
+	return id, ok && id != ""
+}
+
+// expandConfigEnv - 문자열 값의 ${NAME}, ${NAME:-기본값}을 환경 변수로 치환 (키는 치환하지 않음)
+// ${NAME:-기본값}은 변수가 없거나 빈 값이면 기본값, ${NAME}은 빈 값도 그대로 사용
+// 기본값 없이 정의되지 않은 변수는 오류 (빈 MinIO 경로 등으로 조용히 동작하지 않도록)
+// replaced는 치환으로 바뀐 값이 하나라도 있으면 true
+func expandConfigEnv(value interface{}, path string) (interface{}, bool, error) {
+	replaced := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			expanded, changed, err := expandConfigEnv(item, path+"."+key)
+			if err != nil {
+				return nil, false, err
+			}
+			v[key] = expanded
+			replaced = replaced || changed
+		}
+	case []interface{}:
+		for i, item := range v {
+			expanded, changed, err := expandConfigEnv(item, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, false, err
+			}
+			v[i] = expanded
+			replaced = replaced || changed
+		}
+	case string:
+		var missing []string
+		expanded := configEnvPattern.ReplaceAllStringFunc(v, func(match string) string {
+			groups := configEnvPattern.FindStringSubmatch(match)
+			env, ok := os.LookupEnv(groups[1])
+			if groups[2] != "" && env == "" {
+				// 셸과 같이 :-는 변수가 없거나 빈 값이면 기본값 사용
+				return groups[3]
+			}
+			if ok {
+				return env
+			}
+			missing = append(missing, groups[1])
+			return match
+		})
+		if len(missing) > 0 {
+			return nil, false, &ConfigDecodeError{Path: path, Err: fmt.Errorf("환경 변수 %s가 설정되지 않았습니다", strings.Join(missing, ", "))}
+		}
+		return expanded, expanded != v, nil
+	}
+	return value, replaced, nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// mustDecodeLayer - 테스트용 JSON을 범용 값으로 디코딩
+func mustDecodeLayer(t *testing.T, data string) interface{} {
+	t.Helper()
+	layer, err := decodeConfigLayer([]byte(data))
+	if err != nil {
+		t.Fatalf("decodeConfigLayer(%s): %v", data, err)
+	}
+	return layer
+}
+
+func TestMergeConfigValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		overlay string
+		want    string
+		wantErr string // ConfigDecodeError.Path
+	}{
+		{
+			name:    "objects merge by key",
+			base:    `{"a": {"x": 1, "y": 2}, "b": "base"}`,
+			overlay: `{"a": {"y": 3, "z": 4}}`,
+			want:    `{"a": {"x": 1, "y": 3, "z": 4}, "b": "base"}`,
+		},
+		{
+			name:    "null deletes key",
+			base:    `{"a": {"x": 1, "y": 2}}`,
+			overlay: `{"a": {"x": null}}`,
+			want:    `{"a": {"y": 2}}`,
+		},
+		{
+			name:    "arrays are replaced",
+			base:    `{"tiers": [{"name": "small"}, {"name": "large"}]}`,
+			overlay: `{"tiers": [{"name": "all"}]}`,
+			want:    `{"tiers": [{"name": "all"}]}`,
+		},
+		{
+			name: "config_specs merge by provision_id",
+			base: `{"config_specs": [
+				{"provision_id": "a", "enabled": true, "build_number": {"number": "13"}},
+				{"provision_id": "b", "enabled": true}
+			]}`,
+			overlay: `{"config_specs": [
+				{"provision_id": "b", "enabled": false},
+				{"provision_id": "a", "build_number": {"number": "14"}},
+				{"provision_id": "c", "enabled": true}
+			]}`,
+			want: `{"config_specs": [
+				{"provision_id": "a", "enabled": true, "build_number": {"number": "14"}},
+				{"provision_id": "b", "enabled": false},
+				{"provision_id": "c", "enabled": true}
+			]}`,
+		},
+		{
+			name:    "overlay spec without provision_id",
+			base:    `{"config_specs": [{"provision_id": "a"}]}`,
+			overlay: `{"config_specs": [{"enabled": false}]}`,
+			wantErr: "$.config_specs[0].provision_id",
+		},
+		{
+			name:    "config_specs must be an array",
+			base:    `{"config_specs": []}`,
+			overlay: `{"config_specs": {"provision_id": "a"}}`,
+			wantErr: "$.config_specs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := mergeConfigValue(mustDecodeLayer(t, tt.base), mustDecodeLayer(t, tt.overlay), "$")
+			if tt.wantErr != "" {
+				var decodeErr *ConfigDecodeError
+				if !errors.As(err, &decodeErr) || decodeErr.Path != tt.wantErr {
+					t.Fatalf("mergeConfigValue error = %v, want error at %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("mergeConfigValue error = %v", err)
+			}
+			if want := mustDecodeLayer(t, tt.want); !reflect.DeepEqual(merged, want) {
+				got, _ := json.Marshal(merged)
+				t.Errorf("merged = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandConfigEnv(t *testing.T) {
+	t.Setenv("HYNIX_TEST_PREFIX", "bucket/prod")
+	t.Setenv("HYNIX_TEST_EMPTY", "")
+
+	tests := []struct {
+		name         string
+		value        string
+		want         string
+		wantReplaced bool
+		wantErr      bool
+	}{
+		{name: "set variable", value: "${HYNIX_TEST_PREFIX}/<<service_id>>", want: "bucket/prod/<<service_id>>", wantReplaced: true},
+		{name: "set variable ignores default", value: "${HYNIX_TEST_PREFIX:-bucket/dev}", want: "bucket/prod", wantReplaced: true},
+		{name: "unset variable uses default", value: "${HYNIX_TEST_UNSET:-bucket/dev}/x", want: "bucket/dev/x", wantReplaced: true},
+		{name: "empty variable uses default", value: "${HYNIX_TEST_EMPTY:-bucket/dev}", want: "bucket/dev", wantReplaced: true},
+		{name: "empty default", value: "a${HYNIX_TEST_UNSET:-}b", want: "ab", wantReplaced: true},
+		{name: "empty variable without default", value: "a${HYNIX_TEST_EMPTY}b", want: "ab", wantReplaced: true},
+		{name: "several variables", value: "${HYNIX_TEST_PREFIX}:${HYNIX_TEST_UNSET:-9000}", want: "bucket/prod:9000", wantReplaced: true},
+		{name: "no variables", value: "<<service_id>>", want: "<<service_id>>"},
+		{name: "not a variable name", value: "echo ${1} $HOME", want: "echo ${1} $HOME"},
+		{name: "unset variable without default", value: "${HYNIX_TEST_UNSET}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, replaced, err := expandConfigEnv(tt.value, "$.value")
+			if tt.wantErr {
+				var decodeErr *ConfigDecodeError
+				if !errors.As(err, &decodeErr) || decodeErr.Path != "$.value" {
+					t.Fatalf("expandConfigEnv error = %v, want error at $.value", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandConfigEnv error = %v", err)
+			}
+			if got != tt.want || replaced != tt.wantReplaced {
+				t.Errorf("expandConfigEnv(%q) = %q (replaced %v), want %q (replaced %v)", tt.value, got, replaced, tt.want, tt.wantReplaced)
+			}
+		})
+	}
+}
+
+func TestReadConfigFiles(t *testing.T) {
+	t.Setenv("HYNIX_TEST_PREFIX", "bucket/prod")
+	dir := t.TempDir()
+	writeFile := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	base := writeFile("config.json", `{"config_specs": [{"provision_id": "a", "enabled": true, "resource_calculation": {"minio": "bucket/dev/<<service_id>>"}}]}`)
+	overlay := writeFile("config.prod.json", `{"config_specs": [{"provision_id": "a", "resource_calculation": {"minio": "${HYNIX_TEST_PREFIX}/<<service_id>>"}}]}`)
+	broken := writeFile("broken.json", `{"config_specs": [`)
+	literal := writeFile("literal.json", `{"config_specs": [{"provision_id": "a", "arguments": "--home ${1} $SPARK_HOME"}]}`)
+	expanded := writeFile("expanded.json", `{"config_specs": [{"provision_id": "a", "resource_calculation": {"minio": "${HYNIX_TEST_PREFIX}/<<service_id>>"}}]}`)
+
+	// 파일 하나는 치환된 값이 있을 때만 composed
+	for _, tt := range []struct {
+		path         string
+		wantComposed bool
+	}{
+		{path: base},
+		{path: literal},
+		{path: expanded, wantComposed: true},
+	} {
+		data, composed, err := ReadConfigFiles([]string{tt.path})
+		if err != nil || composed != tt.wantComposed {
+			t.Fatalf("%s = (composed %v, %v), want composed %v", filepath.Base(tt.path), composed, err, tt.wantComposed)
+		}
+		raw, _ := os.ReadFile(tt.path)
+		if !composed && string(data) != string(raw) {
+			t.Errorf("%s was rewritten, want raw file content", filepath.Base(tt.path))
+		}
+	}
+
+	data, composed, err := ReadConfigFiles([]string{base, overlay})
+	if err != nil || !composed {
+		t.Fatalf("layered files = (composed %v, %v), want composed", composed, err)
+	}
+	config, err := decodeConfig(data)
+	if err != nil {
+		t.Fatalf("decode merged config: %v", err)
+	}
+	spec := config.ConfigSpecs[0]
+	if !spec.Enabled || spec.ResourceCalculation.Minio != "bucket/prod/<<service_id>>" {
+		t.Errorf("merged spec = %+v, want enabled with overlay minio path", spec)
+	}
+
+	_, _, err = ReadConfigFiles([]string{base, broken})
+	var layerErr *ConfigLayerError
+	if !errors.As(err, &layerErr) || layerErr.File != broken {
+		t.Errorf("broken overlay error = %v, want ConfigLayerError for %s", err, broken)
+	}
+}
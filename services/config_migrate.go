@@ -0,0 +1,42 @@
+package services
+
+import "service-common/metrics"
+
+// MigrateConfigFile - 레거시 resource_calculation(threshold/min_queue/max_queue)을 tiers 형식으로 변환한 config.json 내용 반환
+// 변환된 provision_id 목록과 변환 후 다시 검증한 결과를 함께 반환 (파싱/검증 error가 있으면 data는 nil)
+// 표준 형식으로 다시 쓰이므로 "true"/"2" 같은 문자열 값도 boolean/정수로 바뀜
+func MigrateConfigFile(configPath, templateDir string) (data []byte, migrated []string, issues ConfigIssues) {
+	config, issues := ValidateConfigFile(configPath, templateDir)
+	if config == nil || issues.HasErrors() {
+		return nil, nil, issues
+	}
+
+	for i := range config.ConfigSpecs {
+		rc := &config.ConfigSpecs[i].ResourceCalculation
+		if rc.Legacy != nil {
+			migrated = append(migrated, config.ConfigSpecs[i].ProvisionID)
+			rc.Legacy = nil
+		}
+	}
+
+	data, err := marshalConfig(config)
+	if err != nil {
+		return nil, migrated, ConfigIssues{{Path: "$", Severity: SeverityError, Message: err.Error()}}
+	}
+	return data, migrated, ValidateConfig(config, templateDir)
+}
+
+// WriteConfigFile - config.json 원자적 기록 (임시 파일 + rename, 기존 파일 권한 유지)
+func WriteConfigFile(configPath string, data []byte) error {
+	return writeFileAtomic(configPath, data)
+}
+
+// recordLegacyConfigMetrics - 레거시 resource_calculation을 사용하는 프로비저닝 표시
+func recordLegacyConfigMetrics(config *Config) {
+	metrics.ConfigLegacySpecs.Reset()
+	for i := range config.ConfigSpecs {
+		if spec := &config.ConfigSpecs[i]; spec.ResourceCalculation.Legacy != nil {
+			metrics.ConfigLegacySpecs.WithLabelValues(spec.ProvisionID).Set(1)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestLegacyResourceCalculationToTiers(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		wantTiers []ResourceTier
+		wantErr   string // ConfigDecodeError.Path
+	}{
+		{
+			name: "threshold with gang executor",
+			spec: `{"resource_calculation": {"minio": "b/<<service_id>>", "threshold": 1000, "min_queue": "q.min", "max_queue": "q.max"},
+				"gang_scheduling": {"cpu": "1", "memory": "2", "executor": "3"}}`,
+			wantTiers: []ResourceTier{
+				{Name: "small", MaxSize: 1000, Queue: "q.min", Executor: 3},
+				{Name: "large", MinSize: 1000, Queue: "q.max", Executor: 3},
+			},
+		},
+		{
+			name: "missing gang executor defaults to one",
+			spec: `{"resource_calculation": {"threshold": 1000, "min_queue": "q.min", "max_queue": "q.max"}}`,
+			wantTiers: []ResourceTier{
+				{Name: "small", MaxSize: 1000, Queue: "q.min", Executor: 1},
+				{Name: "large", MinSize: 1000, Queue: "q.max", Executor: 1},
+			},
+		},
+		{
+			name: "invalid gang executor defaults to one",
+			spec: `{"resource_calculation": {"threshold": 1000, "min_queue": "q.min", "max_queue": "q.max"},
+				"gang_scheduling": {"executor": "0"}}`,
+			wantTiers: []ResourceTier{
+				{Name: "small", MaxSize: 1000, Queue: "q.min", Executor: 1},
+				{Name: "large", MinSize: 1000, Queue: "q.max", Executor: 1},
+			},
+		},
+		{
+			name:    "threshold must be positive",
+			spec:    `{"resource_calculation": {"threshold": 0, "min_queue": "q.min", "max_queue": "q.max"}}`,
+			wantErr: "$.config_specs[0].resource_calculation.threshold",
+		},
+		{
+			name:    "tiers and threshold together",
+			spec:    `{"resource_calculation": {"threshold": 1000, "tiers": [{"queue": "q", "executor": 1}]}}`,
+			wantErr: "$.config_specs[0].resource_calculation.tiers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := decodeConfig([]byte(`{"config_specs": [` + tt.spec + `]}`))
+			if tt.wantErr != "" {
+				var decodeErr *ConfigDecodeError
+				if !errors.As(err, &decodeErr) || decodeErr.Path != tt.wantErr {
+					t.Fatalf("decodeConfig error = %v, want error at %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeConfig error = %v", err)
+			}
+
+			rc := config.ConfigSpecs[0].ResourceCalculation
+			if rc.Legacy == nil {
+				t.Fatal("Legacy = nil, want original legacy values")
+			}
+			if !reflect.DeepEqual(rc.Tiers, tt.wantTiers) {
+				t.Errorf("tiers = %+v, want %+v", rc.Tiers, tt.wantTiers)
+			}
+		})
+	}
+}
+
+func TestLegacyTiersKeepThresholdBoundary(t *testing.T) {
+	tiers := legacyTiers(&LegacyResourceCalculation{Threshold: 1000, MinQueue: "q.min", MaxQueue: "q.max"}, "2")
+
+	tests := []struct {
+		size      int64
+		wantQueue string
+	}{
+		{size: 0, wantQueue: "q.min"},
+		{size: 999, wantQueue: "q.min"},
+		{size: 1000, wantQueue: "q.max"},
+		{size: 1 << 40, wantQueue: "q.max"},
+	}
+	for _, tt := range tests {
+		if got := selectTierBySize(tt.size, tiers).Queue; got != tt.wantQueue {
+			t.Errorf("selectTierBySize(%d) = %s, want %s", tt.size, got, tt.wantQueue)
+		}
+	}
+}
+
+func TestMigrateConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	original := `{"config_specs": [
+		{"provision_id": "0001_legacy", "enabled": "true",
+			"resource_calculation": {"minio": "b/<<service_id>>", "threshold": 1000, "min_queue": "q.min", "max_queue": "q.max"},
+			"gang_scheduling": {"cpu": "1", "memory": "2", "executor": "2"},
+			"build_number": {"number": "13"}},
+		{"provision_id": "0002_tiers", "enabled": true,
+			"resource_calculation": {"minio": "b/<<service_id>>", "tiers": [{"name": "all", "queue": "q", "executor": 1}]},
+			"gang_scheduling": {"cpu": "1", "memory": "2"},
+			"build_number": {"number": "13"}}
+	]}`
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, migrated, issues := MigrateConfigFile(path, "")
+	if issues.HasErrors() || data == nil {
+		t.Fatalf("MigrateConfigFile issues = %v", issues)
+	}
+	if !slices.Equal(migrated, []string{"0001_legacy"}) {
+		t.Errorf("migrated = %v, want [0001_legacy]", migrated)
+	}
+	for _, legacyField := range []string{"threshold", "min_queue", "max_queue"} {
+		if bytes.Contains(data, []byte(legacyField)) {
+			t.Errorf("migrated config still contains %s:\n%s", legacyField, data)
+		}
+	}
+
+	config, err := decodeConfig(data)
+	if err != nil {
+		t.Fatalf("decode migrated config: %v", err)
+	}
+	spec := config.ConfigSpecs[0]
+	if spec.ResourceCalculation.Legacy != nil || !spec.Enabled {
+		t.Errorf("migrated spec = %+v, want enabled tiers form", spec)
+	}
+	want := []ResourceTier{
+		{Name: "small", MaxSize: 1000, Queue: "q.min", Executor: 2},
+		{Name: "large", MinSize: 1000, Queue: "q.max", Executor: 2},
+	}
+	if !reflect.DeepEqual(spec.ResourceCalculation.Tiers, want) {
+		t.Errorf("migrated tiers = %+v, want %+v", spec.ResourceCalculation.Tiers, want)
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"slices"
+	"sort"
+)
+
+// ProvisionOverride - 요청의 category/region에 따라 프로비저닝 설정 일부를 교체
+// category와 region 중 하나 이상을 지정해야 하며, 지정한 필드만 통째로 교체됨 (tiers는 목록 전체)
+type ProvisionOverride struct {
+	Category       string          `json:"category,omitempty"`
+	Region         string          `json:"region,omitempty"`
+	Tiers          []ResourceTier  `json:"tiers,omitempty"`
+	GangScheduling *GangScheduling `json:"gang_scheduling,omitempty"`
+	BuildNumber    *BuildNumber    `json:"build_number,omitempty"`
+	Image          *ImageSettings  `json:"image,omitempty"`     // 지역 레지스트리 미러 등 (image 전체 교체)
+	Namespace      string          `json:"namespace,omitempty"` // SparkApplication metadata.namespace
+}
+
+// AppliedOverride - 적용된 override (decision trace 용, 적용 순서대로)
+type AppliedOverride struct {
+	Index    int      `json:"index"` // overrides 배열 인덱스
+	Category string   `json:"category,omitempty"`
+	Region   string   `json:"region,omitempty"`
+	Fields   []string `json:"fields"` // 교체한 필드 (tiers, gang_scheduling, build_number, image, namespace)
+}
+
+// ResolvedProvision - overrides를 적용한 프로비저닝 설정
+type ResolvedProvision struct {
+	Spec      *ConfigSpec       // overrides가 적용된 사본 (원본 설정은 변경하지 않음)
+	Namespace string            // override된 namespace (비어 있으면 템플릿의 값 사용)
+	Applied   []AppliedOverride // 적용 순서대로 (뒤에 적용된 override가 우선)
+}
+
+// ResolveProvision - 요청의 category/region에 맞는 overrides를 적용
+// 구체적인 override가 나중에 적용되어 우선함: region만 지정 → category만 지정 → category+region 지정
+// 같은 단계에서는 overrides 배열 순서대로 적용 (검증에서 같은 category/region 조합의 중복은 error)
+func ResolveProvision(spec *ConfigSpec, category, region string) *ResolvedProvision {
+	resolved := *spec
+	result := &ResolvedProvision{Spec: &resolved}
+
+	var matched []int
+	for i := range spec.Overrides {
+		if spec.Overrides[i].matches(category, region) {
+			matched = append(matched, i)
+		}
+	}
+	sort.SliceStable(matched, func(a, b int) bool {
+		return spec.Overrides[matched[a]].specificity() < spec.Overrides[matched[b]].specificity()
+	})
+
+	for _, i := range matched {
+		override := &spec.Overrides[i]
+		if override.Tiers != nil {
+			resolved.ResourceCalculation.Tiers = override.Tiers
+		}
+		if override.GangScheduling != nil {
+			resolved.GangScheduling = *override.GangScheduling
+		}
+		if override.BuildNumber != nil {
+			resolved.BuildNumber = *override.BuildNumber
+		}
+		if override.Image != nil {
+			resolved.Image = override.Image
+		}
+		if override.Namespace != "" {
+			result.Namespace = override.Namespace
+		}
+		result.Applied = append(result.Applied, AppliedOverride{
+			Index:    i,
+			Category: override.Category,
+			Region:   override.Region,
+			Fields:   override.fields(),
+		})
+	}
+	return result
+}
+
+// matches - 요청의 category/region이 override 조건과 일치하는지 확인 (지정하지 않은 조건은 모두 일치)
+func (o *ProvisionOverride) matches(category, region string) bool {
+	if o.Category == "" && o.Region == "" {
+		return false
+	}
+	return (o.Category == "" || o.Category == category) && (o.Region == "" || o.Region == region)
+}
+
+// specificity - 적용 순서 (클수록 나중에 적용되어 우선)
+func (o *ProvisionOverride) specificity() int {
+	switch {
+	case o.Category != "" && o.Region != "":
+		return 3
+	case o.Category != "":
+		return 2
+	default:
+		return 1
+	}
+}
+
+// fields - override가 교체하는 필드 이름
+func (o *ProvisionOverride) fields() []string {
+	fields := []string{}
+	if o.Tiers != nil {
+		fields = append(fields, "tiers")
+	}
+	if o.GangScheduling != nil {
+		fields = append(fields, "gang_scheduling")
+	}
+	if o.BuildNumber != nil {
+		fields = append(fields, "build_number")
+	}
+	if o.Image != nil {
+		fields = append(fields, "image")
+	}
+	if o.Namespace != "" {
+		fields = append(fields, "namespace")
+	}
+	return fields
+}
+
+// key - 중복 검사용 조건 표현 (category=fsa,region=kr)
+func (o *ProvisionOverride) key() string {
+	return "category=" + o.Category + ",region=" + o.Region
+}
+
+// ProvisionNamespace - 렌더링 시 사용하는 SparkApplication namespace (override가 없으면 DefaultNamespace)
+func ProvisionNamespace(spec *ConfigSpec, category, region string) string {
+	if namespace := ResolveProvision(spec, category, region).Namespace; namespace != "" {
+		return namespace
+	}
+	return DefaultNamespace
+}
+
+// ApplicationNamespaces - lifecycle API가 SparkApplication을 찾을 namespace 후보 (중복 제거, 설정 순서)
+// provisionID가 비어 있으면 모든 프로비저닝, region이 비어 있으면 category가 일치하는 region override의 namespace도 포함
+// 렌더링(ProvisionNamespace)과 같은 규칙이므로 override된 namespace에 생성된 애플리케이션도 namespace 없이 조회 가능
+func ApplicationNamespaces(config *Config, provisionID, category, region string) []string {
+	var namespaces []string
+	add := func(namespace string) {
+		if namespace != "" && !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	for i := range config.ConfigSpecs {
+		spec := &config.ConfigSpecs[i]
+		if provisionID != "" && spec.ProvisionID != provisionID {
+			continue
+		}
+		add(ProvisionNamespace(spec, category, region))
+		if region != "" {
+			continue
+		}
+		for j := range spec.Overrides {
+			override := &spec.Overrides[j]
+			if override.Region != "" && (override.Category == "" || override.Category == category) {
+				add(ProvisionNamespace(spec, category, override.Region))
+			}
+		}
+	}
+	if len(namespaces) == 0 {
+		add(DefaultNamespace)
+	}
+	return namespaces
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// ErrConfigNotPersistent - admin API로 기록한 설정이 재시작/재배포 시 사라지는 위치에 있음
+var ErrConfigNotPersistent = errors.New("설정 저장 위치가 컨테이너 파일시스템이라 재시작하면 변경이 사라집니다")
+
+// CheckPersistent - admin API로 기록하는 config.json 디렉토리와 revision 디렉토리가 영구 볼륨에 있는지 확인
+// 이미지에 포함된 디렉토리에 기록하면 pod 재시작/재배포 시 git의 설정으로 되돌아가므로 시작 시 거부
+// 병합/치환된 설정은 API로 기록하지 않으므로 확인하지 않음
+func (s *ConfigStore) CheckPersistent() error {
+	s.mu.Lock()
+	composed := s.composed
+	s.mu.Unlock()
+	if composed {
+		return nil
+	}
+
+	dirs := []string{filepath.Dir(s.paths[0])}
+	if s.history != nil {
+		dirs = append(dirs, s.history.Dir())
+	}
+	for _, dir := range dirs {
+		ephemeral, err := isEphemeralDir(dir)
+		if err != nil {
+			return fmt.Errorf("설정 저장 위치 확인 실패 (%s): %w", dir, err)
+		}
+		if ephemeral {
+			return fmt.Errorf("%w: %s (영구 볼륨을 마운트하세요)", ErrConfigNotPersistent, dir)
+		}
+	}
+	return nil
+}
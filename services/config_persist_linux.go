@@ -0,0 +1,30 @@
+//go:build linux
+
+package services
+
+import "syscall"
+
+// overlayFSMagic - overlayfs superblock magic (컨테이너 이미지 루트 파일시스템)
+const overlayFSMagic = 0x794c7630
+
+// isEphemeralDir - dir이 컨테이너의 overlay 루트 파일시스템에 있는지 확인
+// 볼륨으로 마운트한 디렉토리는 루트와 장치 번호가 다르므로 영구 저장소로 간주
+func isEphemeralDir(dir string) (bool, error) {
+	var rootFS syscall.Statfs_t
+	if err := syscall.Statfs("/", &rootFS); err != nil {
+		return false, err
+	}
+	if rootFS.Type != overlayFSMagic {
+		// 컨테이너가 아닌 호스트에서 실행
+		return false, nil
+	}
+
+	var root, target syscall.Stat_t
+	if err := syscall.Stat("/", &root); err != nil {
+		return false, err
+	}
+	if err := syscall.Stat(dir, &target); err != nil {
+		return false, err
+	}
+	return root.Dev == target.Dev, nil
+}
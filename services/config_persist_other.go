@@ -0,0 +1,8 @@
+//go:build !linux
+
+package services
+
+// isEphemeralDir - linux 외에서는 컨테이너 파일시스템을 구분하지 않음
+func isEphemeralDir(dir string) (bool, error) {
+	return false, nil
+}
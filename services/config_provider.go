@@ -0,0 +1,50 @@
+package services
+
+import "sync/atomic"
+
+const (
+	// ConfigSourceFile - config.json 파일 (ConfigStore)
+	ConfigSourceFile = "file"
+	// ConfigSourceCRD - ProvisionConfig 커스텀 리소스 (CRDConfigProvider)
+	ConfigSourceCRD = "crd"
+)
+
+// ConfigProvider - 프로비저닝 설정 출처
+// LoadConfig/FindProvisionConfig는 출처와 관계없이 현재 적용 중인 설정만 조회
+type ConfigProvider interface {
+	// Config - 현재 적용 중인 설정 (호출자는 수정하면 안 됨)
+	Config() *Config
+	// Source - 설정 출처 (file, crd)
+	Source() string
+}
+
+// ConfigWriter - API로 변경할 수 있는 설정 출처 (ConfigStore)
+type ConfigWriter interface {
+	ConfigProvider
+	// UpdateConfig - 현재 설정 사본에 mutate를 적용하고 검증을 통과하면 저장
+	// 검증 error가 있으면 아무것도 저장하지 않고 *ConfigInvalidError 반환
+	UpdateConfig(change ConfigChange, mutate func(config *Config) error) (*Config, ConfigIssues, error)
+	// RestoreConfig - 설정 원문(이전 revision)을 검증해 그대로 저장
+	RestoreConfig(data []byte, change ConfigChange) (*Config, ConfigIssues, error)
+}
+
+// configProviderRef - atomic.Pointer에 인터페이스를 보관하기 위한 래퍼
+type configProviderRef struct {
+	provider ConfigProvider
+}
+
+// activeConfigProvider - LoadConfig가 사용하는 설정 출처 (SetConfigProvider 호출 전에는 nil)
+var activeConfigProvider atomic.Pointer[configProviderRef]
+
+// SetConfigProvider - LoadConfig가 사용할 설정 출처 지정
+func SetConfigProvider(provider ConfigProvider) {
+	activeConfigProvider.Store(&configProviderRef{provider: provider})
+}
+
+// ActiveConfigProvider - 현재 설정 출처 (지정되지 않았으면 nil)
+func ActiveConfigProvider() ConfigProvider {
+	if ref := activeConfigProvider.Load(); ref != nil {
+		return ref.provider
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"service-common/metrics"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// DefaultConfigReloadInterval - 파일 이벤트를 놓친 경우를 대비한 체크섬 확인 주기
+	DefaultConfigReloadInterval = 30 * time.Second
+
+	// configReloadDebounce - 연속된 파일 이벤트(쓰기 도중 등)를 하나의 재로드로 묶는 대기 시간
+	configReloadDebounce = 200 * time.Millisecond
+)
+
+// ConfigStore - 파싱된 config.json을 메모리에 보관하고 파일 변경 시 재로드
+// 요청 처리 중에는 atomic pointer로 현재 설정을 읽기만 하므로 재로드와 경합하지 않음
+// 파일이 여러 개이면 순서대로 병합한 결과를 사용 (ReadConfigFiles)
+type ConfigStore struct {
+	paths   []string
+	name    string         // 로그용 파일 목록
+	history *ConfigHistory // nil이면 revision을 기록하지 않음
+	config  atomic.Pointer[Config]
+
+	mu       sync.Mutex // 재로드 직렬화
+	checksum string     // 마지막으로 적용한 설정 내용의 sha256
+	rejected string     // 마지막으로 검증에 실패한 설정 내용의 sha256 (같은 내용을 반복 보고하지 않음)
+	composed bool       // 병합/${ENV} 치환된 설정이면 true (admin API로 기록 불가)
+}
+
+// NewConfigStore - 설정 파일을 읽어 설정 저장소 생성
+// 최초 로드는 반드시 성공해야 함 (보관할 이전 설정이 없으므로)
+func NewConfigStore(paths []string, history *ConfigHistory) (*ConfigStore, error) {
+	store := &ConfigStore{paths: paths, name: strings.Join(paths, " + "), history: history}
+	if _, err := store.Reload(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// StartConfigStore - 설정 저장소를 생성해 LoadConfig의 설정 출처로 지정하고 변경 감시 시작
+// ctx가 취소되면 감시를 중단
+func StartConfigStore(ctx context.Context, paths []string, interval time.Duration, history *ConfigHistory) (*ConfigStore, error) {
+	store, err := NewConfigStore(paths, history)
+	if err != nil {
+		return nil, err
+	}
+	SetConfigProvider(store)
+
+	go store.Watch(ctx, interval)
+	return store, nil
+}
+
+// Config - 현재 적용 중인 설정 (호출자는 수정하면 안 됨)
+func (s *ConfigStore) Config() *Config {
+	return s.config.Load()
+}
+
+// Source - 설정 출처 (ConfigProvider)
+func (s *ConfigStore) Source() string {
+	return ConfigSourceFile
+}
+
+// Checksum - 현재 적용 중인 config.json 내용의 sha256
+func (s *ConfigStore) Checksum() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checksum
+}
+
+// Reload - 파일 내용이 바뀌었으면 다시 파싱/검증해 교체
+// 검증에 실패하면 이전 설정을 유지하고 오류 반환
+func (s *ConfigStore) Reload() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, composed, err := ReadConfigFiles(s.paths)
+	if err != nil {
+		return false, s.rejectLocked(err)
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if checksum == s.checksum {
+		// 잘못된 파일이 적용 중인 설정과 같은 내용으로 되돌려진 경우
+		if s.rejected != "" {
+			s.rejected = ""
+			metrics.ConfigReloadFailing.Set(0)
+			log.Printf("config 파일이 적용 중인 설정으로 복구됨 (checksum: %.12s)", checksum)
+		}
+		return false, nil
+	}
+	if checksum == s.rejected {
+		return false, nil
+	}
+
+	config, issues := ValidateConfigData(data, TemplateDir())
+	if err := issues.Err(); err != nil {
+		s.rejected = checksum
+		return false, s.rejectLocked(err)
+	}
+	for _, issue := range issues {
+		log.Printf("config 검증 %s", issue)
+	}
+
+	previous := s.checksum
+	s.composed = composed
+	s.applyLocked(config, data, checksum, ConfigChange{Source: ConfigChangeFile})
+	if previous == "" {
+		log.Printf("config 로드 완료: %s (checksum: %.12s, revision: %d, specs: %d)", s.name, checksum, config.Revision, len(config.ConfigSpecs))
+	} else {
+		log.Printf("config 재로드 완료: %s (checksum: %.12s -> %.12s, revision: %d, specs: %d)", s.name, previous, checksum, config.Revision, len(config.ConfigSpecs))
+	}
+	return true, nil
+}
+
+// UpdateConfig - 현재 설정 사본에 mutate를 적용하고, 검증을 통과하면 config.json에 원자적으로 기록
+// 같은 디렉토리의 임시 파일에 쓴 뒤 rename하므로 감시/재로드 중에 반쯤 쓰인 파일이 읽히지 않음
+// 기록한 내용의 체크섬을 바로 적용하므로 이어지는 파일 이벤트는 재로드를 일으키지 않음
+func (s *ConfigStore) UpdateConfig(change ConfigChange, mutate func(config *Config) error) (*Config, ConfigIssues, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writableLocked(); err != nil {
+		return nil, nil, err
+	}
+
+	config, err := cloneConfig(s.config.Load())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := mutate(config); err != nil {
+		return nil, nil, err
+	}
+
+	issues := ValidateConfig(config, TemplateDir())
+	if issues.HasErrors() {
+		return nil, issues, &ConfigInvalidError{Issues: issues}
+	}
+
+	data, err := marshalConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.writeLocked(config, data, change); err != nil {
+		return nil, nil, err
+	}
+	return config, issues, nil
+}
+
+// RestoreConfig - config.json 내용을 data로 교체 (이전 revision 롤백)
+// 저장된 원문을 그대로 기록하므로 롤백 후 파일은 해당 revision과 바이트 단위로 같음
+func (s *ConfigStore) RestoreConfig(data []byte, change ConfigChange) (*Config, ConfigIssues, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writableLocked(); err != nil {
+		return nil, nil, err
+	}
+
+	config, issues := ValidateConfigData(data, TemplateDir())
+	if issues.HasErrors() {
+		return nil, issues, &ConfigInvalidError{Issues: issues}
+	}
+	if err := s.writeLocked(config, data, change); err != nil {
+		return nil, nil, err
+	}
+	return config, issues, nil
+}
+
+// writableLocked - admin API로 기록할 수 있는지 확인
+// 병합/치환된 설정을 기본 파일에 쓰면 overlay와 환경 변수 값이 기본 파일에 고정되므로 거부
+func (s *ConfigStore) writableLocked() error {
+	if s.composed {
+		return fmt.Errorf("%w (여러 파일을 병합하거나 ${ENV}를 사용하는 설정: %s)", ErrConfigReadOnly, s.name)
+	}
+	return nil
+}
+
+// writeLocked - 검증된 설정을 config.json에 원자적으로 기록하고 적용
+func (s *ConfigStore) writeLocked(config *Config, data []byte, change ConfigChange) error {
+	if err := writeFileAtomic(s.paths[0], data); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	previous := s.checksum
+	s.applyLocked(config, data, checksum, change)
+	log.Printf("config 변경 저장 (%s): %s (checksum: %.12s -> %.12s, revision: %d, specs: %d)",
+		change.Source, s.paths[0], previous, checksum, config.Revision, len(config.ConfigSpecs))
+	return nil
+}
+
+// applyLocked - 검증된 설정을 revision으로 기록하고 현재 설정으로 교체
+// revision 기록에 실패해도 설정은 적용함 (이 경우 Revision은 0이고 CR에 revision annotation이 붙지 않음)
+func (s *ConfigStore) applyLocked(config *Config, data []byte, checksum string, change ConfigChange) {
+	if s.history != nil {
+		revision, err := s.history.Record(data, checksum, change)
+		if err != nil {
+			log.Printf("config revision 기록 실패: %v", err)
+		} else {
+			config.Revision = revision.Revision
+		}
+	}
+
+	s.config.Store(config)
+	s.checksum = checksum
+	s.rejected = ""
+
+	recordLegacyConfigMetrics(config)
+	metrics.ConfigReloads.WithLabelValues("success").Inc()
+	metrics.ConfigLastReloadSuccess.SetToCurrentTime()
+	metrics.ConfigReloadFailing.Set(0)
+}
+
+// marshalConfig - config.json 형식으로 직렬화 (minio 경로의 <<service_id>>가 이스케이프되지 않도록 HTML 이스케이프 해제)
+func marshalConfig(config *Config) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(config); err != nil {
+		return nil, fmt.Errorf("config.json 직렬화 실패: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// cloneConfig - 설정 깊은 복사 (변경 실패 시 적용 중인 설정이 오염되지 않도록)
+func cloneConfig(config *Config) (*Config, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("config 복사 실패: %w", err)
+	}
+	return decodeConfig(data)
+}
+
+// writeFileAtomic - 임시 파일에 기록 후 rename (기존 파일 권한 유지)
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // rename 성공 후에는 존재하지 않음
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("임시 파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("임시 파일 동기화 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("임시 파일 닫기 실패: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("임시 파일 권한 설정 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%s 교체 실패: %w", path, err)
+	}
+	return nil
+}
+
+// configDirs - 감시할 디렉토리 목록 (중복 제거)
+func configDirs(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	var dirs []string
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// rejectLocked - 재로드 실패 기록 (이전 설정이 있으면 그대로 유지)
+func (s *ConfigStore) rejectLocked(err error) error {
+	metrics.ConfigReloads.WithLabelValues("invalid").Inc()
+	if s.checksum != "" {
+		metrics.ConfigReloadFailing.Set(1)
+		log.Printf("config 재로드 실패, 이전 설정 유지 (checksum: %.12s): %v", s.checksum, err)
+	}
+	return err
+}
+
+// Watch - 파일 변경 감시 (fsnotify + 주기적 체크섬 확인)
+// 파일을 교체(rename)하는 방식의 쓰기도 감지하도록 파일이 아닌 디렉토리를 감시
+func (s *ConfigStore) Watch(ctx context.Context, interval time.Duration) {
+	var events <-chan fsnotify.Event
+	var watchErrors <-chan error
+
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		for _, dir := range configDirs(s.paths) {
+			if err = watcher.Add(dir); err != nil {
+				// 주기 확인으로 대체하므로 감시자(inotify fd, 이벤트 goroutine)를 바로 정리
+				watcher.Close()
+				break
+			}
+		}
+	}
+	if err != nil {
+		log.Printf("config 파일 감시 실패, %s 주기 확인만 사용: %v", interval, err)
+	} else {
+		defer watcher.Close()
+		events = watcher.Events
+		watchErrors = watcher.Errors
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	debounce := time.NewTimer(configReloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			debounce.Reset(configReloadDebounce)
+		case err, ok := <-watchErrors:
+			if !ok {
+				watchErrors = nil
+				continue
+			}
+			log.Printf("config 파일 감시 오류: %v", err)
+		case <-debounce.C:
+			s.Reload()
+		case <-ticker.C:
+			s.Reload()
+		}
+	}
+}
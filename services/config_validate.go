@@ -0,0 +1,521 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ConfigIssueSeverity - 설정 검증 결과 심각도
+type ConfigIssueSeverity string
+
+const (
+	// SeverityError - 적용하면 안 되는 설정 (시작 거부, 재로드 거부)
+	SeverityError ConfigIssueSeverity = "error"
+	// SeverityWarning - 동작은 하지만 의도와 다를 수 있는 설정
+	SeverityWarning ConfigIssueSeverity = "warning"
+)
+
+// DefaultTemplateDir - 템플릿 파일 기본 디렉토리
+const DefaultTemplateDir = "./template"
+
+// templateDir - 템플릿 파일 디렉토리 (TEMPLATE_DIR, 서버 시작 시 SetTemplateDir로 지정)
+var templateDir = DefaultTemplateDir
+
+// SetTemplateDir - 템플릿 디렉토리 지정 (요청 처리 시작 전에 호출)
+func SetTemplateDir(dir string) {
+	if dir != "" {
+		templateDir = dir
+	}
+}
+
+// TemplateDir - 현재 템플릿 디렉토리
+func TemplateDir() string {
+	return templateDir
+}
+
+// provisionIDPattern - provision_id 형식 (openapi.yaml의 ProvisionIDPattern과 동일)
+var provisionIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ConfigIssue - 설정 검증에서 발견한 문제 (path는 JSON 경로, 예: $.config_specs[0].enabled)
+type ConfigIssue struct {
+	Path     string              `json:"path"`
+	Severity ConfigIssueSeverity `json:"severity"`
+	Message  string              `json:"message"`
+}
+
+// String - "error $.config_specs[0].enabled: ..." 형식
+func (i ConfigIssue) String() string {
+	return fmt.Sprintf("%s %s: %s", i.Severity, i.Path, i.Message)
+}
+
+// ConfigIssues - 설정 검증 결과 목록
+type ConfigIssues []ConfigIssue
+
+// HasErrors - error 심각도 문제가 있는지 확인
+func (issues ConfigIssues) HasErrors() bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Err - error 심각도 문제를 하나의 error로 묶어 반환 (없으면 nil)
+func (issues ConfigIssues) Err() error {
+	var messages []string
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			messages = append(messages, issue.Path+": "+issue.Message)
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("config 검증 실패 (%d건): %s", len(messages), strings.Join(messages, "; "))
+}
+
+// ValidateConfigFile - config.json 파일을 읽어 검증
+// templateDir가 비어 있지 않으면 프로비저닝별 템플릿 파일 존재 여부도 확인
+func ValidateConfigFile(configPath, templateDir string) (*Config, ConfigIssues) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, ConfigIssues{{Path: "$", Severity: SeverityError, Message: fmt.Sprintf("파일 읽기 실패: %v", err)}}
+	}
+	return ValidateConfigData(data, templateDir)
+}
+
+// ValidateConfigFiles - 설정 파일들을 병합/치환한 결과를 검증 (ReadConfigFiles)
+// 파일 읽기/병합 오류는 해당 파일 이름을 메시지 앞에 붙여 반환
+func ValidateConfigFiles(paths []string, templateDir string) (*Config, ConfigIssues) {
+	data, _, err := ReadConfigFiles(paths)
+	if err != nil {
+		var layerErr *ConfigLayerError
+		if errors.As(err, &layerErr) {
+			issue := jsonErrorIssue(layerErr.Err)
+			issue.Message = layerErr.File + ": " + issue.Message
+			return nil, ConfigIssues{issue}
+		}
+		return nil, ConfigIssues{jsonErrorIssue(err)}
+	}
+	return ValidateConfigData(data, templateDir)
+}
+
+// ValidateConfigData - config.json 내용을 파싱하고 검증
+// 파싱에 실패하면 config는 nil
+func ValidateConfigData(data []byte, templateDir string) (*Config, ConfigIssues) {
+	config, err := parseConfig(data)
+	if err != nil {
+		return nil, ConfigIssues{jsonErrorIssue(err)}
+	}
+	return config, ValidateConfig(config, templateDir)
+}
+
+// ValidateConfig - 파싱된 설정의 모든 문제를 JSON 경로와 함께 반환
+func ValidateConfig(config *Config, templateDir string) ConfigIssues {
+	v := &configValidator{templateDir: templateDir}
+
+	if len(config.ConfigSpecs) == 0 {
+		v.errorf("$.config_specs", "config_specs가 비어 있습니다")
+	}
+
+	seen := make(map[string]int, len(config.ConfigSpecs))
+	for i := range config.ConfigSpecs {
+		spec := &config.ConfigSpecs[i]
+		path := fmt.Sprintf("$.config_specs[%d]", i)
+
+		if first, ok := seen[spec.ProvisionID]; ok && spec.ProvisionID != "" {
+			v.errorf(path+".provision_id", "provision_id %s가 $.config_specs[%d]와 중복되었습니다", spec.ProvisionID, first)
+		} else {
+			seen[spec.ProvisionID] = i
+		}
+		v.validateSpec(path, spec)
+	}
+
+	return v.issues
+}
+
+// configValidator - 검증 중 발견한 문제 누적
+type configValidator struct {
+	templateDir string
+	issues      ConfigIssues
+}
+
+func (v *configValidator) errorf(path, format string, args ...interface{}) {
+	v.issues = append(v.issues, ConfigIssue{Path: path, Severity: SeverityError, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *configValidator) warnf(path, format string, args ...interface{}) {
+	v.issues = append(v.issues, ConfigIssue{Path: path, Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)})
+}
+
+// validateSpec - 프로비저닝 설정 하나 검증
+func (v *configValidator) validateSpec(path string, spec *ConfigSpec) {
+	switch {
+	case spec.ProvisionID == "":
+		v.errorf(path+".provision_id", "provision_id가 비어 있습니다")
+	case !provisionIDPattern.MatchString(spec.ProvisionID):
+		v.errorf(path+".provision_id", "provision_id %q는 영문/숫자/_/-만 사용할 수 있습니다", spec.ProvisionID)
+	case v.templateDir != "":
+		templatePath := filepath.Join(v.templateDir, strings.ReplaceAll(spec.ProvisionID, "-", "_")+".yaml")
+		if _, err := os.Stat(templatePath); err != nil {
+			v.warnf(path+".provision_id", "템플릿 파일 %s가 없습니다", templatePath)
+		}
+	}
+
+	v.validateResourceCalculation(path+".resource_calculation", &spec.ResourceCalculation, spec.Enabled)
+	v.validateGangScheduling(path+".gang_scheduling", &spec.GangScheduling)
+	v.validateBuildNumber(path+".build_number", &spec.BuildNumber)
+	if spec.Image != nil {
+		v.validateImageSettings(path+".image", spec.Image)
+	}
+	v.validateOverrides(path+".overrides", spec.Overrides)
+}
+
+// validateBuildNumber - build_number.number는 minor 버전 숫자
+func (v *configValidator) validateBuildNumber(path string, bn *BuildNumber) {
+	if number := bn.Number; number == "" {
+		v.errorf(path+".number", "build_number.number가 비어 있습니다")
+	} else if _, err := strconv.ParseUint(number, 10, 64); err != nil {
+		v.errorf(path+".number", "build_number.number는 minor 버전 숫자여야 합니다 (현재: %q, 예: \"13\" → 4.13.1)", number)
+	}
+	v.validateImageDigest(path+".digest", bn.Digest)
+	if bn.Canary != nil {
+		v.validateBuildCanary(path+".canary", bn.Canary, bn.Number)
+	}
+}
+
+// validateImageDigest - 이미지 digest 형식 검증 (비어 있으면 태그로만 참조)
+func (v *configValidator) validateImageDigest(path, digest string) {
+	if digest != "" && !imageDigestPattern.MatchString(digest) {
+		v.errorf(path, "digest는 sha256:<64자리 hex> 형식이어야 합니다 (현재: %q)", digest)
+	}
+}
+
+// validateImageSettings - 이미지 저장소와 버전/태그/라벨 형식 검증
+// 형식은 빌드 번호 예시("13")로 만들어 본 값이 image 태그/Kubernetes 라벨 값으로 유효한지 확인
+func (v *configValidator) validateImageSettings(path string, s *ImageSettings) {
+	if repository := s.Repository; repository != "" {
+		slash, colon := strings.LastIndex(repository, "/"), strings.LastIndex(repository, ":")
+		if strings.Contains(repository, "@") || colon > slash {
+			v.errorf(path+".repository", "repository에는 태그나 digest를 포함할 수 없습니다 (현재: %q)", repository)
+		} else if _, err := ParseImageReference(repository); err != nil {
+			v.errorf(path+".repository", "%v", err)
+		}
+	}
+
+	formats := []struct {
+		field, format string
+		allowed       []string
+	}{
+		{"version_format", s.VersionFormat, []string{"number"}},
+		{"tag_format", s.TagFormat, []string{"number", "version"}},
+		{"label_format", s.LabelFormat, []string{"number", "version"}},
+	}
+	valid := true
+	for _, f := range formats {
+		for _, name := range buildFormatPlaceholders(f.format) {
+			if !slices.Contains(f.allowed, name) {
+				v.errorf(path+"."+f.field, "%s에는 {%s}를 사용할 수 없습니다 (사용 가능: {%s})", f.field, name, strings.Join(f.allowed, "}, {"))
+				valid = false
+			}
+		}
+	}
+	if s.VersionFormat != "" && !strings.Contains(s.VersionFormat, "{number}") {
+		v.warnf(path+".version_format", "{number}가 없어 빌드 번호가 바뀌어도 버전이 같습니다")
+	}
+	if s.TagFormat != "" && len(buildFormatPlaceholders(s.TagFormat)) == 0 {
+		v.warnf(path+".tag_format", "{number}나 {version}이 없어 빌드 번호가 바뀌어도 image 태그가 같습니다")
+	}
+	if !valid {
+		return
+	}
+
+	sample := s.Build("13", "")
+	if !imageTagPattern.MatchString(sample.Tag) {
+		v.errorf(path+".tag_format", "image 태그로 사용할 수 없는 값이 만들어집니다 (build_number=13: %q)", sample.Tag)
+	}
+	if errs := validation.IsValidLabelValue(sample.Label); len(errs) > 0 {
+		v.errorf(path+".label_format", "라벨 값으로 사용할 수 없는 값이 만들어집니다 (build_number=13: %q): %s", sample.Label, strings.Join(errs, "; "))
+	}
+}
+
+// validateBuildCanary - 카나리 빌드 번호와 대상(percent/categories) 검증
+func (v *configValidator) validateBuildCanary(path string, canary *BuildCanary, stable string) {
+	if number := canary.Number; number == "" {
+		v.errorf(path+".number", "canary.number가 비어 있습니다")
+	} else if _, err := strconv.ParseUint(number, 10, 64); err != nil {
+		v.errorf(path+".number", "canary.number는 minor 버전 숫자여야 합니다 (현재: %q)", number)
+	} else if number == stable {
+		v.warnf(path+".number", "canary.number가 build_number.number(%s)와 같습니다", stable)
+	}
+	v.validateImageDigest(path+".digest", canary.Digest)
+
+	if canary.Percent < 0 || canary.Percent > 100 {
+		v.errorf(path+".percent", "percent는 0~100이어야 합니다 (현재: %d)", canary.Percent)
+	}
+	seen := make(map[string]bool, len(canary.Categories))
+	for i, category := range canary.Categories {
+		switch {
+		case category == "":
+			v.errorf(fmt.Sprintf("%s.categories[%d]", path, i), "category가 비어 있습니다")
+		case seen[category]:
+			v.warnf(fmt.Sprintf("%s.categories[%d]", path, i), "category %s가 중복되었습니다", category)
+		}
+		seen[category] = true
+	}
+	if canary.Percent == 0 && len(canary.Categories) == 0 {
+		v.warnf(path, "percent와 categories가 모두 비어 있어 카나리 빌드를 사용하는 요청이 없습니다")
+	}
+}
+
+// validateOverrides - category/region override 검증
+// 같은 category/region 조합이 두 번 나오면 어느 쪽이 적용될지 설정만 보고 알기 어려우므로 error
+func (v *configValidator) validateOverrides(path string, overrides []ProvisionOverride) {
+	seen := make(map[string]int, len(overrides))
+	for i := range overrides {
+		override := &overrides[i]
+		overridePath := fmt.Sprintf("%s[%d]", path, i)
+
+		if override.Category == "" && override.Region == "" {
+			v.errorf(overridePath, "category와 region 중 하나 이상을 지정해야 합니다")
+			continue
+		}
+		if first, ok := seen[override.key()]; ok {
+			v.errorf(overridePath, "%s 조건이 overrides[%d]와 중복되었습니다", override.key(), first)
+		} else {
+			seen[override.key()] = i
+		}
+
+		if len(override.fields()) == 0 {
+			v.warnf(overridePath, "교체할 필드가 없습니다 (tiers, gang_scheduling, build_number, image, namespace)")
+		}
+		if override.Tiers != nil {
+			if len(override.Tiers) == 0 {
+				v.errorf(overridePath+".tiers", "tiers를 교체하려면 1개 이상 필요합니다")
+			}
+			v.validateTiers(overridePath+".tiers", override.Tiers)
+		}
+		if override.GangScheduling != nil {
+			v.validateGangScheduling(overridePath+".gang_scheduling", override.GangScheduling)
+		}
+		if override.BuildNumber != nil {
+			v.validateBuildNumber(overridePath+".build_number", override.BuildNumber)
+		}
+		if override.Image != nil {
+			v.validateImageSettings(overridePath+".image", override.Image)
+		}
+		if override.Namespace != "" {
+			if errs := validation.IsDNS1123Label(override.Namespace); len(errs) > 0 {
+				v.errorf(overridePath+".namespace", "namespace %q가 올바르지 않습니다: %s", override.Namespace, strings.Join(errs, "; "))
+			}
+		}
+	}
+}
+
+// validateResourceCalculation - MinIO 경로와 티어 구간 검증
+// enabled=false인 경우 사용되지 않으므로 필수 항목 누락은 검사하지 않음
+func (v *configValidator) validateResourceCalculation(path string, rc *ResourceCalculation, enabled bool) {
+	if legacy := rc.Legacy; legacy != nil {
+		v.warnf(path, "레거시 형식(threshold=%d, min_queue=%s, max_queue=%s)은 더 이상 사용되지 않습니다. tiers 2개로 변환해 사용 중이며 'hynix config migrate'로 파일을 변환하세요",
+			legacy.Threshold, legacy.MinQueue, legacy.MaxQueue)
+	}
+	if rc.Minio == "" && enabled {
+		v.errorf(path+".minio", "enabled=true인 프로비저닝은 minio 경로가 필요합니다")
+	}
+	if rc.MinioServer != nil {
+		v.validateMinIOServer(path+".minio_server", rc.MinioServer)
+	}
+	if len(rc.Tiers) == 0 {
+		if enabled {
+			v.errorf(path+".tiers", "enabled=true인 프로비저닝은 tiers가 1개 이상 필요합니다")
+		}
+		return
+	}
+	v.validateTiers(path+".tiers", rc.Tiers)
+}
+
+// validateMinIOServer - 프로비저닝별 MinIO 서버 검증
+// 접근 키 환경 변수는 배포 환경에만 있으므로 검증하지 않음 (없으면 요청 시 trace warning)
+func (v *configValidator) validateMinIOServer(path string, server *MinIOConfig) {
+	switch endpoint := server.Endpoint; {
+	case endpoint == "":
+		v.errorf(path+".endpoint", "endpoint가 비어 있습니다 (예: minio.example.com:9000)")
+	case strings.Contains(endpoint, "://") || strings.Contains(endpoint, "/"):
+		v.errorf(path+".endpoint", "endpoint는 host:port 형식이어야 합니다 (현재: %q, https는 use_ssl=true)", endpoint)
+	}
+
+	if server.CAFile != "" {
+		if !server.UseSSL {
+			v.warnf(path+".ca_file", "use_ssl=false이면 ca_file을 사용하지 않습니다")
+		}
+		if _, err := os.Stat(server.CAFile); err != nil {
+			v.warnf(path+".ca_file", "CA 파일 %s를 읽을 수 없습니다: %v", server.CAFile, err)
+		}
+	}
+}
+
+// validateTiers - 티어 값과 크기 구간 검증
+func (v *configValidator) validateTiers(path string, tiers []ResourceTier) {
+	names := make(map[string]int, len(tiers))
+	for i, tier := range tiers {
+		tierPath := fmt.Sprintf("%s[%d]", path, i)
+
+		if tier.Name == "" {
+			v.warnf(tierPath+".name", "티어 이름이 비어 있습니다 (decision trace에서 구분할 수 없음)")
+		} else if first, ok := names[tier.Name]; ok {
+			v.warnf(tierPath+".name", "티어 이름 %s가 tiers[%d]와 중복되었습니다", tier.Name, first)
+		} else {
+			names[tier.Name] = i
+		}
+
+		if tier.Queue == "" {
+			v.errorf(tierPath+".queue", "queue가 비어 있습니다")
+		}
+		v.validateExecutor(tierPath+".executor", tier.Executor)
+
+		if tier.MinSize < 0 {
+			v.errorf(tierPath+".min_size", "min_size는 0 이상이어야 합니다")
+		}
+		if tier.MaxSize < 0 {
+			v.errorf(tierPath+".max_size", "max_size는 0 이상이어야 합니다")
+		}
+		if tier.MaxSize > 0 && tier.MaxSize <= tier.MinSize {
+			v.errorf(tierPath+".max_size", "max_size(%d)가 min_size(%d)보다 커야 합니다", tier.MaxSize, tier.MinSize)
+		}
+	}
+
+	v.validateTierBands(path, tiers)
+}
+
+// validateExecutor - executor는 양의 정수
+func (v *configValidator) validateExecutor(path string, executor int) {
+	if executor < 1 {
+		v.errorf(path, "executor는 1 이상의 정수여야 합니다 (현재: %d)", executor)
+	}
+}
+
+// tierBand - 티어가 담당하는 크기 구간 [min, max), max=0이면 상한 없음
+type tierBand struct {
+	index int
+	name  string
+	min   int64
+	max   int64
+}
+
+func (b tierBand) upper() int64 {
+	if b.max <= 0 {
+		return math.MaxInt64
+	}
+	return b.max
+}
+
+func (b tierBand) String() string {
+	if b.max <= 0 {
+		return fmt.Sprintf("[%s, ∞)", FormatBytes(b.min))
+	}
+	return fmt.Sprintf("[%s, %s)", FormatBytes(b.min), FormatBytes(b.max))
+}
+
+// validateTierBands - 티어 크기 구간의 겹침과 빈 구간 검사 (warning)
+// 티어는 선언 순서대로 첫 번째로 범위에 포함되는 티어가 선택되므로 겹치는 구간의 뒤쪽 티어는 선택되지 않음
+// 기존 설정이 이 선택 순서에 의존할 수 있으므로 겹침도 거부하지 않고 warning으로만 보고
+// 어느 티어에도 포함되지 않는 크기는 마지막 티어로 처리됨
+func (v *configValidator) validateTierBands(path string, tiers []ResourceTier) {
+	bands := make([]tierBand, 0, len(tiers))
+	for i, tier := range tiers {
+		band := tierBand{index: i, name: tier.Name, min: tier.MinSize, max: tier.MaxSize}
+		if band.min < 0 || band.max < 0 || (band.max > 0 && band.max <= band.min) {
+			continue // 위에서 이미 오류로 보고됨
+		}
+		bands = append(bands, band)
+	}
+
+	for j := range bands {
+		for i := 0; i < j; i++ {
+			lo := max(bands[i].min, bands[j].min)
+			hi := min(bands[i].upper(), bands[j].upper())
+			if lo < hi {
+				overlap := tierBand{min: lo}
+				if hi != math.MaxInt64 {
+					overlap.max = hi
+				}
+				v.warnf(fmt.Sprintf("%s[%d]", path, bands[j].index),
+					"티어 %s %s가 tiers[%d](%s) %s와 겹칩니다 (%s 구간은 항상 %s가 선택됨)",
+					bands[j].name, bands[j], bands[i].index, bands[i].name, bands[i], overlap, bands[i].name)
+			}
+		}
+	}
+
+	sorted := append([]tierBand(nil), bands...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].min < sorted[b].min })
+
+	var covered int64
+	for _, band := range sorted {
+		if band.min > covered {
+			gap := tierBand{min: covered, max: band.min}
+			v.warnf(path, "%s 구간을 담당하는 티어가 없습니다 (마지막 티어가 선택됨)", gap)
+		}
+		if band.upper() > covered {
+			covered = band.upper()
+		}
+	}
+	if covered != math.MaxInt64 && len(sorted) > 0 {
+		gap := tierBand{min: covered}
+		v.warnf(path, "%s 구간을 담당하는 티어가 없습니다 (마지막 티어의 max_size를 생략하세요)", gap)
+	}
+}
+
+// validateGangScheduling - gang_scheduling 값 검증 (누락 시 메트릭이 0으로 기록됨)
+func (v *configValidator) validateGangScheduling(path string, gs *GangScheduling) {
+	if *gs == (GangScheduling{}) {
+		v.warnf(path, "gang_scheduling이 없습니다 (cpu/memory 메트릭이 0으로 기록됨)")
+		return
+	}
+	if _, err := strconv.ParseFloat(gs.CPU, 64); err != nil {
+		v.errorf(path+".cpu", "cpu는 숫자여야 합니다 (현재: %q)", gs.CPU)
+	}
+	if _, err := strconv.ParseFloat(gs.Memory, 64); err != nil {
+		v.errorf(path+".memory", "memory는 숫자여야 합니다 (현재: %q)", gs.Memory)
+	}
+	if gs.Executor != "" {
+		if n, err := strconv.Atoi(gs.Executor); err != nil || n < 1 {
+			v.errorf(path+".executor", "executor는 1 이상의 정수여야 합니다 (현재: %q)", gs.Executor)
+		}
+	}
+}
+
+// jsonErrorIssue - JSON 파싱 오류를 위치 정보가 있는 ConfigIssue로 변환
+func jsonErrorIssue(err error) ConfigIssue {
+	path := "$"
+	var decodeErr *ConfigDecodeError
+	if errors.As(err, &decodeErr) {
+		path = decodeErr.Path
+		err = decodeErr.Err
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return ConfigIssue{Path: path, Severity: SeverityError,
+			Message: fmt.Sprintf("%s 타입이어야 합니다 (현재: %s)", typeErr.Type, typeErr.Value)}
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return ConfigIssue{Path: path, Severity: SeverityError,
+			Message: fmt.Sprintf("JSON 문법 오류 (offset %d): %v", syntaxErr.Offset, syntaxErr)}
+	}
+
+	return ConfigIssue{Path: path, Severity: SeverityError, Message: err.Error()}
+}
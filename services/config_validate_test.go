@@ -0,0 +1,131 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	testMiB = int64(1) << 20
+	testGiB = int64(1) << 30
+)
+
+func TestValidateTiers(t *testing.T) {
+	tests := []struct {
+		name  string
+		tiers []ResourceTier
+		want  []ConfigIssue // Message는 포함 여부만 비교
+	}{
+		{
+			name: "contiguous tiers",
+			tiers: []ResourceTier{
+				{Name: "small", MaxSize: 10 * testMiB, Queue: "q.small", Executor: 1},
+				{Name: "medium", MinSize: 10 * testMiB, MaxSize: testGiB, Queue: "q.medium", Executor: 2},
+				{Name: "large", MinSize: testGiB, Queue: "q.large", Executor: 4},
+			},
+		},
+		{
+			name: "single open tier",
+			tiers: []ResourceTier{
+				{Name: "all", Queue: "q", Executor: 1},
+			},
+		},
+		{
+			name: "overlap is a warning",
+			tiers: []ResourceTier{
+				{Name: "small", MaxSize: 10 * testMiB, Queue: "q.small", Executor: 1},
+				{Name: "medium", MinSize: 10 * testMiB, Queue: "q.medium", Executor: 2},
+				{Name: "large", MinSize: testGiB, Queue: "q.large", Executor: 4},
+			},
+			want: []ConfigIssue{
+				{Path: "$.tiers[2]", Severity: SeverityWarning, Message: "tiers[1](medium)"},
+			},
+		},
+		{
+			name: "gap between tiers",
+			tiers: []ResourceTier{
+				{Name: "small", MaxSize: 10 * testMiB, Queue: "q.small", Executor: 1},
+				{Name: "large", MinSize: testGiB, Queue: "q.large", Executor: 4},
+			},
+			want: []ConfigIssue{
+				{Path: "$.tiers", Severity: SeverityWarning, Message: "[10.0 MiB, 1.0 GiB) 구간"},
+			},
+		},
+		{
+			name: "gap at the start",
+			tiers: []ResourceTier{
+				{Name: "large", MinSize: testGiB, Queue: "q.large", Executor: 4},
+			},
+			want: []ConfigIssue{
+				{Path: "$.tiers", Severity: SeverityWarning, Message: "[0 B, 1.0 GiB) 구간"},
+			},
+		},
+		{
+			name: "no open upper tier",
+			tiers: []ResourceTier{
+				{Name: "small", MaxSize: testGiB, Queue: "q.small", Executor: 1},
+			},
+			want: []ConfigIssue{
+				{Path: "$.tiers", Severity: SeverityWarning, Message: "max_size를 생략하세요"},
+			},
+		},
+		{
+			name: "invalid values are errors",
+			tiers: []ResourceTier{
+				{Name: "small", MinSize: testGiB, MaxSize: testMiB, Executor: 0},
+				{Name: "small", Queue: "q", Executor: 1},
+			},
+			want: []ConfigIssue{
+				{Path: "$.tiers[0].queue", Severity: SeverityError},
+				{Path: "$.tiers[0].executor", Severity: SeverityError},
+				{Path: "$.tiers[0].max_size", Severity: SeverityError},
+				{Path: "$.tiers[1].name", Severity: SeverityWarning, Message: "중복"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &configValidator{}
+			v.validateTiers("$.tiers", tt.tiers)
+
+			if len(v.issues) != len(tt.want) {
+				t.Fatalf("issues = %v, want %d issue(s)", v.issues, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := v.issues[i]
+				if got.Path != want.Path || got.Severity != want.Severity || !strings.Contains(got.Message, want.Message) {
+					t.Errorf("issues[%d] = %s, want %s %s containing %q", i, got, want.Severity, want.Path, want.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfigDataOverlapDoesNotFail(t *testing.T) {
+	data := []byte(`{"config_specs": [{
+		"provision_id": "0001_wfbm",
+		"enabled": true,
+		"resource_calculation": {
+			"minio": "bucket/<<service_id>>/",
+			"tiers": [
+				{"name": "small", "max_size": 1048576, "queue": "q.small", "executor": 1},
+				{"name": "medium", "min_size": 1048576, "queue": "q.medium", "executor": 2},
+				{"name": "large", "min_size": 1073741824, "queue": "q.large", "executor": "4"}
+			]
+		},
+		"gang_scheduling": {"cpu": "1", "memory": "2"},
+		"build_number": {"number": "13"}
+	}]}`)
+
+	config, issues := ValidateConfigData(data, "")
+	if config == nil {
+		t.Fatalf("config = nil, issues = %v", issues)
+	}
+	if issues.HasErrors() {
+		t.Fatalf("overlapping tiers must not be errors: %v", issues.Err())
+	}
+	if len(issues) != 1 || issues[0].Severity != SeverityWarning {
+		t.Errorf("issues = %v, want one overlap warning", issues)
+	}
+}
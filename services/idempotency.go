@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrIdempotencyInProgress - 같은 Idempotency-Key 요청이 아직 처리 중
+	ErrIdempotencyInProgress = errors.New("같은 Idempotency-Key 요청이 처리 중입니다")
+	// ErrIdempotencyKeyReused - 같은 Idempotency-Key가 다른 요청(쿼리 또는 본문)으로 재사용됨
+	ErrIdempotencyKeyReused = errors.New("Idempotency-Key가 다른 요청으로 재사용되었습니다")
+)
+
+// idempotencySweepInterval - 만료 항목 정리 주기
+const idempotencySweepInterval = time.Minute
+
+// IdempotentResponse - Idempotency-Key로 기억하는 최초 처리 결과
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// idempotencyEntry - 키별 처리 상태
+type idempotencyEntry struct {
+	fingerprint string // 쿼리/요청 본문 해시 (같은 키로 다른 요청이 오는 것 방지)
+	response    *IdempotentResponse
+	expiresAt   time.Time
+}
+
+// IdempotencyStore - Idempotency-Key별 처리 결과를 TTL 동안 보관하는 in-memory 저장소
+type IdempotencyStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]*idempotencyEntry
+	lastSweep time.Time
+}
+
+// NewIdempotencyStore - 결과 보관 기간(ttl)을 지정하여 저장소 생성
+func NewIdempotencyStore(ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		ttl:       ttl,
+		entries:   make(map[string]*idempotencyEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// TTL - 결과 보관 기간
+func (s *IdempotencyStore) TTL() time.Duration {
+	return s.ttl
+}
+
+// Begin - 키 처리 시작
+// 이전 결과가 있으면 그 결과를 반환 (재실행하지 않음)
+// 처리 중이면 ErrIdempotencyInProgress, 다른 요청 본문이면 ErrIdempotencyKeyReused 반환
+// (nil, nil)이면 호출자가 처리 후 Complete 또는 Abort를 호출해야 함
+func (s *IdempotencyStore) Begin(key, fingerprint string) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweepLocked(now)
+
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		if entry.fingerprint != fingerprint {
+			return nil, ErrIdempotencyKeyReused
+		}
+		if entry.response == nil {
+			return nil, ErrIdempotencyInProgress
+		}
+		return entry.response, nil
+	}
+
+	// 처리 중 표시 (처리 시간이 TTL보다 길어도 중복 실행되지 않도록 Complete 시 만료 시각 갱신)
+	s.entries[key] = &idempotencyEntry{
+		fingerprint: fingerprint,
+		expiresAt:   now.Add(s.ttl),
+	}
+	return nil, nil
+}
+
+// Complete - 처리 결과 저장 (TTL 동안 같은 키 요청에 재사용)
+func (s *IdempotencyStore) Complete(key string, response *IdempotentResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	entry.response = response
+	entry.expiresAt = time.Now().Add(s.ttl)
+}
+
+// Abort - 처리 결과를 저장하지 않고 키 해제 (일시적 오류 후 재시도 허용)
+func (s *IdempotencyStore) Abort(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+// sweepLocked - 만료된 항목 정리 (mu 보유 상태에서 호출)
+func (s *IdempotencyStore) sweepLocked(now time.Time) {
+	if now.Sub(s.lastSweep) < idempotencySweepInterval {
+		return
+	}
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
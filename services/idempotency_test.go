@@ -0,0 +1,84 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyStoreBegin(t *testing.T) {
+	stored := &IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)}
+
+	tests := []struct {
+		name         string
+		prepare      func(s *IdempotencyStore)
+		fingerprint  string
+		wantResponse *IdempotentResponse
+		wantErr      error
+	}{
+		{
+			name:        "new key starts processing",
+			prepare:     func(s *IdempotencyStore) {},
+			fingerprint: "a",
+		},
+		{
+			name: "completed key replays response",
+			prepare: func(s *IdempotencyStore) {
+				s.Begin("k", "a")
+				s.Complete("k", stored)
+			},
+			fingerprint:  "a",
+			wantResponse: stored,
+		},
+		{
+			name: "completed key with different fingerprint",
+			prepare: func(s *IdempotencyStore) {
+				s.Begin("k", "a")
+				s.Complete("k", stored)
+			},
+			fingerprint: "b",
+			wantErr:     ErrIdempotencyKeyReused,
+		},
+		{
+			name:        "key in progress",
+			prepare:     func(s *IdempotencyStore) { s.Begin("k", "a") },
+			fingerprint: "a",
+			wantErr:     ErrIdempotencyInProgress,
+		},
+		{
+			name: "aborted key can be retried",
+			prepare: func(s *IdempotencyStore) {
+				s.Begin("k", "a")
+				s.Abort("k")
+			},
+			fingerprint: "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewIdempotencyStore(time.Hour)
+			tt.prepare(s)
+
+			response, err := s.Begin("k", tt.fingerprint)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Begin error = %v, want %v", err, tt.wantErr)
+			}
+			if response != tt.wantResponse {
+				t.Errorf("Begin response = %+v, want %+v", response, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestIdempotencyStoreExpiry(t *testing.T) {
+	s := NewIdempotencyStore(20 * time.Millisecond)
+	s.Begin("k", "a")
+	s.Complete("k", &IdempotentResponse{StatusCode: 201})
+
+	time.Sleep(30 * time.Millisecond)
+	response, err := s.Begin("k", "b")
+	if err != nil || response != nil {
+		t.Fatalf("Begin after TTL = (%v, %v), want a new entry", response, err)
+	}
+}
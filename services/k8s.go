@@ -2,50 +2,125 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
-var k8sClient client.Client
+// k8sClient - 공용 Kubernetes 클라이언트 (SparkApplication 상태 스트림을 위해 watch 지원)
+var k8sClient client.WithWatch
 
-// initK8sClient - Kubernetes 클라이언트 초기화
+// k8sClientMu - 동시 요청에서 클라이언트가 한 번만 생성되도록 보호
+var k8sClientMu sync.Mutex
+
+const (
+	// FieldManager - server-side apply 시 사용하는 field manager 이름
+	FieldManager = "hynix"
+
+	// deleteWaitTimeout - 기존 리소스를 삭제 후 다시 생성할 때 삭제 완료를 기다리는 최대 시간
+	deleteWaitTimeout = 60 * time.Second
+	// deleteWaitInterval - 삭제 완료 확인 주기
+	deleteWaitInterval = 500 * time.Millisecond
+)
+
+// sparkApplicationGVK - SparkApplication CR의 GroupVersionKind
+var sparkApplicationGVK = schema.GroupVersionKind{
+	Group:   "sparkoperator.k8s.io",
+	Version: "v1beta2",
+	Kind:    "SparkApplication",
+}
+
+// ErrKubernetesUnavailable - Kubernetes 클라이언트를 만들 수 없음 (config 로드/클라이언트 생성 실패)
+var ErrKubernetesUnavailable = errors.New("Kubernetes API 서버에 연결할 수 없음")
+
+// IsKubernetesUnavailable - 클라이언트 초기화 실패 또는 API 서버 연결/응답 불가로 요청을 수행하지 못한 경우
+// 리소스 없음, 충돌, 검증 실패 등 API 서버가 응답한 오류는 false
+func IsKubernetesUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrKubernetesUnavailable) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return apierrors.IsServiceUnavailable(err) || apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err)
+}
+
+// initK8sClient - Kubernetes 클라이언트 초기화 (실패 시 ErrKubernetesUnavailable로 감싸서 반환)
 func initK8sClient() error {
+	k8sClientMu.Lock()
+	defer k8sClientMu.Unlock()
+
 	if k8sClient != nil {
 		return nil
 	}
 
+	cfg, err := loadRestConfig()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrKubernetesUnavailable, err)
+	}
+
+	// 클라이언트 생성
+	k8sClient, err = client.NewWithWatch(cfg, client.Options{})
+	if err != nil {
+		return fmt.Errorf("%w: Kubernetes 클라이언트 생성 실패: %w", ErrKubernetesUnavailable, err)
+	}
+
+	log.Println("Kubernetes 클라이언트 초기화 완료")
+	return nil
+}
+
+// SetKubernetesClient - SparkApplication 생성/조회에 사용할 Kubernetes 클라이언트 지정
+// 지정하면 in-cluster config/kubeconfig로 클라이언트를 만들지 않음 (테스트의 fake 클라이언트 등)
+func SetKubernetesClient(c client.WithWatch) {
+	k8sClientMu.Lock()
+	defer k8sClientMu.Unlock()
+	k8sClient = c
+}
+
+// loadRestConfig - Kubernetes API 접속 설정 로드
+func loadRestConfig() (*rest.Config, error) {
 	// 클러스터 내부에서 실행 시 in-cluster config 사용
 	cfg, err := rest.InClusterConfig()
 	if err != nil {
 		// 로컬 개발 환경을 위해 kubeconfig 사용 시도
 		cfg, err = config.GetConfig()
 		if err != nil {
-			return fmt.Errorf("Kubernetes config 로드 실패: %w", err)
+			return nil, fmt.Errorf("Kubernetes config 로드 실패: %w", err)
 		}
 		// TLS 인증서 검증 건너뛰기 (minikube 개발 환경)
 		cfg.TLSClientConfig.Insecure = true
 		cfg.TLSClientConfig.CAFile = ""
 	}
-
-	// 클라이언트 생성
-	k8sClient, err = client.New(cfg, client.Options{})
-	if err != nil {
-		return fmt.Errorf("Kubernetes 클라이언트 생성 실패: %w", err)
-	}
-
-	log.Println("Kubernetes 클라이언트 초기화 완료")
-	return nil
+	return cfg, nil
 }
 
 // CreateSparkApplicationCRFromYAML - YAML 문자열로 Kubernetes에 SparkApplication CR 생성
-func CreateSparkApplicationCRFromYAML(yamlStr string) (*CreateResult, error) {
+// 새로 만들 때는 Create를 사용하므로 조회 후 다른 요청이 먼저 생성했으면 덮어쓰지 않고 ConflictError 반환
+// 같은 이름의 SparkApplication이 이미 있으면 policy에 따라 거부(ConflictError)하거나
+// 삭제하지 않고 server-side apply(field manager: hynix)로 교체 (replaceSparkApplication)
+func CreateSparkApplicationCRFromYAML(yamlStr string, policy ConflictPolicy) (*CreateResult, error) {
 	// 클라이언트 초기화
 	if err := initK8sClient(); err != nil {
 		return nil, err
@@ -53,67 +128,306 @@ func CreateSparkApplicationCRFromYAML(yamlStr string) (*CreateResult, error) {
 
 	ctx := context.Background()
 
-	// YAML을 Unstructured로 파싱
-	u := &unstructured.Unstructured{}
-	if err := yaml.Unmarshal([]byte(yamlStr), u); err != nil {
-		return nil, fmt.Errorf("YAML 파싱 실패: %w", err)
+	u, err := parseSparkApplicationYAML(yamlStr)
+	if err != nil {
+		return nil, err
 	}
 
-	// GVK 설정
-	u.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "sparkoperator.k8s.io",
-		Version: "v1beta2",
-		Kind:    "SparkApplication",
-	})
-
 	// 이름과 네임스페이스 추출
 	name := u.GetName()
 	namespace := u.GetNamespace()
 
-	if name == "" {
-		return nil, fmt.Errorf("이름이 없습니다")
+	// 이미 존재하는지 확인
+	existing, err := getSparkApplication(ctx, namespace, name)
+	switch {
+	case err == nil:
+		state, _, _ := unstructured.NestedString(existing.Object, "status", "applicationState", "state")
+		if !policy.allowsReplace(state) {
+			return nil, &ConflictError{
+				Namespace: namespace,
+				Name:      name,
+				State:     state,
+				Policy:    policy,
+			}
+		}
+
+		// 정책상 교체가 허용되면 apply로 교체 (변경할 수 없는 필드가 바뀐 경우에만 삭제 후 재생성)
+		recreated, err := replaceSparkApplication(ctx, existing, u, policy)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("기존 SparkApplication 교체됨: %s/%s (state: %s, on_conflict: %s, recreated: %v)", namespace, name, state, policy, recreated)
+		return newCreateResult(u, true, recreated), nil
+	case !apierrors.IsNotFound(err):
+		return nil, err
 	}
-	if namespace == "" {
-		namespace = "default"
-		u.SetNamespace(namespace)
+
+	if err := createSparkApplication(ctx, u, policy); err != nil {
+		return nil, err
 	}
+	log.Printf("SparkApplication 생성됨: %s/%s", namespace, name)
+	return newCreateResult(u, false, false), nil
+}
 
-	// 이미 존재하는지 확인
-	var existing unstructured.Unstructured
-	existing.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "sparkoperator.k8s.io",
-		Version: "v1beta2",
-		Kind:    "SparkApplication",
-	})
+// createSparkApplication - SparkApplication 생성 (이미 있으면 덮어쓰지 않고 ConflictError)
+func createSparkApplication(ctx context.Context, u *unstructured.Unstructured, policy ConflictPolicy) error {
+	if err := k8sClient.Create(ctx, u, client.FieldOwner(FieldManager)); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// 조회와 생성 사이에 다른 요청이 같은 이름으로 먼저 생성함 (먼저 생성한 요청을 유지)
+			return newConflictError(ctx, u.GetNamespace(), u.GetName(), policy)
+		}
+		return fmt.Errorf("SparkApplication 생성 실패: %w", err)
+	}
+	return nil
+}
 
-	err := k8sClient.Get(ctx, client.ObjectKey{
-		Name:      name,
-		Namespace: namespace,
-	}, &existing)
+// replaceSparkApplication - 기존 SparkApplication을 삭제하지 않고 server-side apply로 교체
+// spec.driver.annotations의 submission id를 매번 바꾸므로 spec이 같아도 변경으로 인식되어
+// Spark operator가 기존 driver/executor를 정리하고 다시 제출함 (실행 중이면 중단 후 재실행)
+// Create로 만든 리소스는 hynix의 필드 소유권을 먼저 apply로 옮겨야 새 YAML에서 빠진 필드(arguments 등)가 제거됨
+// API 서버가 변경할 수 없는 필드(immutable)라며 거부한 경우에만 삭제 완료를 기다린 뒤 다시 생성 (recreated=true)
+func replaceSparkApplication(ctx context.Context, existing, u *unstructured.Unstructured, policy ConflictPolicy) (bool, error) {
+	if err := unstructured.SetNestedField(u.Object, uuid.NewString(), "spec", "driver", "annotations", AnnotationSubmissionID); err != nil {
+		return false, fmt.Errorf("submission id 설정 실패: %w", err)
+	}
+	if err := adoptCreatedFields(ctx, existing); err != nil {
+		return false, fmt.Errorf("SparkApplication 필드 소유권 변경 실패: %w", err)
+	}
 
+	err := k8sClient.Patch(ctx, u, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
 	if err == nil {
-		// 이미 존재하면 삭제 후 재생성
-		if err := k8sClient.Delete(ctx, &existing); err != nil {
-			return nil, fmt.Errorf("기존 리소스 삭제 실패: %w", err)
+		return false, nil
+	}
+	if !isImmutableFieldError(err) {
+		return false, fmt.Errorf("SparkApplication 교체 실패: %w", err)
+	}
+
+	log.Printf("변경할 수 없는 필드가 바뀌어 SparkApplication을 삭제 후 다시 생성: %s/%s (%v)", u.GetNamespace(), u.GetName(), err)
+	if err := deleteAndWait(ctx, existing); err != nil {
+		return false, err
+	}
+	u.SetResourceVersion("")
+	if err := createSparkApplication(ctx, u, policy); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// adoptCreatedFields - Create(Update 작업)로 기록된 hynix의 필드 소유권을 Apply 작업으로 변경
+// 이미 apply로 관리되는 리소스는 변경하지 않음, 그 사이 operator가 상태를 갱신했으면(409) 다시 조회해 재시도
+func adoptCreatedFields(ctx context.Context, existing *unstructured.Unstructured) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		patch, err := csaupgrade.UpgradeManagedFieldsPatch(existing, sets.New(FieldManager), FieldManager)
+		if err != nil || patch == nil {
+			return err
 		}
-		log.Printf("기존 SparkApplication 삭제됨: %s/%s", namespace, name)
+		err = k8sClient.Patch(ctx, existing, client.RawPatch(types.JSONPatchType, patch))
+		if apierrors.IsConflict(err) {
+			latest, getErr := getSparkApplication(ctx, existing.GetNamespace(), existing.GetName())
+			if getErr != nil {
+				return getErr
+			}
+			existing.Object = latest.Object
+		}
+		return err
+	})
+}
+
+// isImmutableFieldError - API 서버(CRD 검증 규칙/웹훅)가 변경할 수 없는 필드의 변경이라며 거부한 오류인지 확인
+func isImmutableFieldError(err error) bool {
+	if !apierrors.IsInvalid(err) {
+		return false
 	}
+	var statusErr *apierrors.StatusError
+	if errors.As(err, &statusErr) && statusErr.ErrStatus.Details != nil {
+		for _, cause := range statusErr.ErrStatus.Details.Causes {
+			if strings.Contains(strings.ToLower(cause.Message), "immutable") {
+				return true
+			}
+		}
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "immutable")
+}
 
-	// 새로 생성
-	if err := k8sClient.Create(ctx, u); err != nil {
-		return nil, fmt.Errorf("SparkApplication 생성 실패: %w", err)
+// deleteAndWait - SparkApplication 삭제 후 API 서버에서 사라질 때까지 대기
+// 삭제가 끝나기 전에 생성하면 삭제 중인 객체와 충돌하므로 완전히 제거된 것을 확인
+func deleteAndWait(ctx context.Context, existing *unstructured.Unstructured) error {
+	if err := k8sClient.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("기존 리소스 삭제 실패: %w", err)
 	}
 
-	log.Printf("SparkApplication 생성됨: %s/%s", namespace, name)
+	deadline := time.Now().Add(deleteWaitTimeout)
+	for time.Now().Before(deadline) {
+		_, err := getSparkApplication(ctx, existing.GetNamespace(), existing.GetName())
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		time.Sleep(deleteWaitInterval)
+	}
+	return fmt.Errorf("기존 리소스 삭제 대기 시간 초과: %s/%s", existing.GetNamespace(), existing.GetName())
+}
 
+// newCreateResult - 제출한 SparkApplication의 생성 결과 (빌드 번호/트랙은 라벨에서 읽음)
+func newCreateResult(u *unstructured.Unstructured, replaced, recreated bool) *CreateResult {
+	labels := u.GetLabels()
 	return &CreateResult{
-		Name:      name,
-		Namespace: namespace,
-	}, nil
+		Name:         u.GetName(),
+		Namespace:    u.GetNamespace(),
+		Replaced:     replaced,
+		Recreated:    recreated,
+		BuildVersion: labels[LabelBuildNumber],
+		BuildTrack:   labels[LabelBuildTrack],
+	}
 }
 
 // CreateResult - CR 생성 결과
 type CreateResult struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
+	Replaced  bool   `json:"replaced"`            // 기존 리소스를 교체한 경우 true
+	Recreated bool   `json:"recreated,omitempty"` // 변경할 수 없는 필드 때문에 교체 시 삭제 후 다시 생성한 경우 true
+
+	BuildVersion string `json:"build_version,omitempty"` // 제출한 빌드 (build-number 라벨)
+	BuildTrack   string `json:"build_track,omitempty"`   // stable, canary (build-track 라벨)
+}
+
+// parseSparkApplicationYAML - YAML 문자열을 SparkApplication Unstructured 객체로 파싱
+// 이름이 없으면 오류, 네임스페이스가 없으면 default로 설정
+func parseSparkApplicationYAML(yamlStr string) (*unstructured.Unstructured, error) {
+	// YAML을 Unstructured로 파싱
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(yamlStr), u); err != nil {
+		return nil, fmt.Errorf("YAML 파싱 실패: %w", err)
+	}
+
+	// GVK 설정
+	u.SetGroupVersionKind(sparkApplicationGVK)
+
+	if u.GetName() == "" {
+		return nil, fmt.Errorf("이름이 없습니다")
+	}
+	if u.GetNamespace() == "" {
+		u.SetNamespace("default")
+	}
+
+	return u, nil
+}
+
+// ValidationResult - Kubernetes API 서버 dry-run 검증 결과
+type ValidationResult struct {
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors,omitempty"` // 스키마/어드미션 웹훅 거부 사유
+}
+
+// ValidateSparkApplicationYAML - SparkApplication을 DryRunAll로 API 서버에 전송하여 검증
+// 스키마(OpenAPI) 검증과 어드미션 웹훅을 모두 거치지만 실제로 저장되지는 않음
+// 반환되는 error는 API 서버 연결 실패 등 검증 자체를 수행하지 못한 경우에만 사용
+func ValidateSparkApplicationYAML(yamlStr string) (*ValidationResult, error) {
+	u, err := parseSparkApplicationYAML(yamlStr)
+	if err != nil {
+		// 파싱 실패는 API 서버에 보낼 수 없는 YAML이므로 검증 실패로 처리
+		return &ValidationResult{Valid: false, Errors: []string{err.Error()}}, nil
+	}
+
+	// 클라이언트 초기화
+	if err := initK8sClient(); err != nil {
+		return nil, err
+	}
+
+	// 실제 제출과 동일하게 server-side apply로 검증 (기존 리소스가 있어도 AlreadyExists 없이 검증 가능)
+	ctx := context.Background()
+	err = k8sClient.Patch(ctx, u, client.Apply, client.DryRunAll, client.FieldOwner(FieldManager), client.ForceOwnership)
+	if err == nil {
+		return &ValidationResult{Valid: true}, nil
+	}
+
+	switch {
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err), apierrors.IsForbidden(err):
+		return &ValidationResult{Valid: false, Errors: validationErrorMessages(err)}, nil
+	default:
+		return nil, fmt.Errorf("SparkApplication dry-run 검증 실패: %w", err)
+	}
+}
+
+// validationErrorMessages - API 서버 오류에서 필드별 원인 메시지 추출
+func validationErrorMessages(err error) []string {
+	statusErr, ok := err.(apierrors.APIStatus)
+	if !ok {
+		return []string{err.Error()}
+	}
+
+	status := statusErr.Status()
+	if status.Details == nil || len(status.Details.Causes) == 0 {
+		return []string{status.Message}
+	}
+
+	messages := make([]string, 0, len(status.Details.Causes))
+	for _, cause := range status.Details.Causes {
+		if cause.Field != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+		} else {
+			messages = append(messages, cause.Message)
+		}
+	}
+	return messages
+}
+
+// ConflictPolicy - 같은 이름의 SparkApplication이 이미 존재할 때의 처리 정책
+type ConflictPolicy string
+
+const (
+	// ConflictReject - 기존 리소스가 있으면 항상 거부 (기본값)
+	ConflictReject ConflictPolicy = "reject"
+	// ConflictReplaceIfTerminal - 기존 리소스가 종료 상태(COMPLETED/FAILED 등)일 때만 교체
+	ConflictReplaceIfTerminal ConflictPolicy = "replace-if-terminal"
+	// ConflictForce - 실행 중이어도 기존 리소스를 삭제하고 교체
+	ConflictForce ConflictPolicy = "force"
+)
+
+// ParseConflictPolicy - on_conflict 값 파싱 (빈 문자열은 reject)
+func ParseConflictPolicy(value string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(value); policy {
+	case "":
+		return ConflictReject, nil
+	case ConflictReject, ConflictReplaceIfTerminal, ConflictForce:
+		return policy, nil
+	}
+	return "", fmt.Errorf("지원하지 않는 on_conflict 값: %s (reject, replace-if-terminal, force 중 하나)", value)
+}
+
+// allowsReplace - 기존 리소스 상태에서 교체가 허용되는지 확인
+func (p ConflictPolicy) allowsReplace(state string) bool {
+	switch p {
+	case ConflictForce:
+		return true
+	case ConflictReplaceIfTerminal:
+		return IsTerminalState(state)
+	}
+	return false
+}
+
+// ConflictError - 기존 SparkApplication이 정책상 교체될 수 없어 제출이 거부된 경우
+type ConflictError struct {
+	Namespace string
+	Name      string
+	State     string
+	Policy    ConflictPolicy
+}
+
+// newConflictError - 동시에 생성된 리소스의 현재 상태로 ConflictError 생성 (조회 실패 시 상태는 비워 둠)
+func newConflictError(ctx context.Context, namespace, name string, policy ConflictPolicy) *ConflictError {
+	conflictErr := &ConflictError{Namespace: namespace, Name: name, Policy: policy}
+	if existing, err := getSparkApplication(ctx, namespace, name); err == nil {
+		conflictErr.State, _, _ = unstructured.NestedString(existing.Object, "status", "applicationState", "state")
+	}
+	return conflictErr
+}
+
+// Error - error 인터페이스 구현
+func (e *ConflictError) Error() string {
+	state := e.State
+	if state == "" {
+		state = "UNKNOWN"
+	}
+	return fmt.Sprintf("SparkApplication %s/%s가 이미 존재합니다 (state: %s, on_conflict: %s)", e.Namespace, e.Name, state, e.Policy)
 }
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestIsImmutableFieldError(t *testing.T) {
+	kind := sparkApplicationGVK.GroupKind()
+	resource := schema.GroupResource{Group: sparkApplicationGVK.Group, Resource: "sparkapplications"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "immutable field", err: apierrors.NewInvalid(kind, "a", field.ErrorList{
+			field.Invalid(field.NewPath("spec", "type"), "Python", "field is immutable"),
+		}), want: true},
+		{name: "wrapped immutable field", err: fmt.Errorf("apply: %w", apierrors.NewInvalid(kind, "a", field.ErrorList{
+			field.Forbidden(field.NewPath("spec", "mode"), "Value is immutable"),
+		})), want: true},
+		{name: "other validation error", err: apierrors.NewInvalid(kind, "a", field.ErrorList{
+			field.Required(field.NewPath("spec", "image"), ""),
+		}), want: false},
+		{name: "conflict", err: apierrors.NewConflict(resource, "a", errors.New("immutable")), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isImmutableFieldError(tt.err); got != tt.want {
+			t.Errorf("%s: isImmutableFieldError(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
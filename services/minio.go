@@ -0,0 +1,192 @@
+package services
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	// DefaultMinIOEndpoint - MINIO_ENDPOINT가 없을 때 사용하는 MinIO 주소
+	DefaultMinIOEndpoint = "localhost:9000"
+	// DefaultMinIOAccessKeyEnv - 접근 키 환경 변수 기본값
+	DefaultMinIOAccessKeyEnv = "MINIO_ROOT_USER"
+	// DefaultMinIOSecretKeyEnv - 비밀 키 환경 변수 기본값
+	DefaultMinIOSecretKeyEnv = "MINIO_ROOT_PASSWORD"
+)
+
+var (
+	defaultMinIOConfig   *MinIOConfig
+	defaultMinIOConfigMu sync.RWMutex
+
+	// minioClients - endpoint/SSL/region/CA/접근 키 조합별로 재사용하는 클라이언트
+	minioClients   = make(map[string]*minio.Client)
+	minioClientsMu sync.Mutex
+)
+
+// MinIOConfigFromEnv - 환경 변수로 기본 MinIO 연결 설정 생성
+// MINIO_ENDPOINT가 https://로 시작하면 MINIO_USE_SSL과 관계없이 SSL 사용
+//
+//	MINIO_ENDPOINT (기본 localhost:9000), MINIO_USE_SSL, MINIO_REGION, MINIO_CA_FILE,
+//	MINIO_ROOT_USER, MINIO_ROOT_PASSWORD
+func MinIOConfigFromEnv() MinIOConfig {
+	cfg := MinIOConfig{
+		Endpoint: os.Getenv("MINIO_ENDPOINT"),
+		UseSSL:   GetEnvBool("MINIO_USE_SSL", false),
+		Region:   os.Getenv("MINIO_REGION"),
+		CAFile:   os.Getenv("MINIO_CA_FILE"),
+	}
+	if rest, ok := strings.CutPrefix(cfg.Endpoint, "https://"); ok {
+		cfg.Endpoint, cfg.UseSSL = rest, true
+	} else if rest, ok := strings.CutPrefix(cfg.Endpoint, "http://"); ok {
+		cfg.Endpoint = rest
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultMinIOEndpoint
+	}
+	return cfg.withCredentials()
+}
+
+// SetMinIOConfig - minio_server가 없는 프로비저닝이 사용할 기본 MinIO 연결 설정 지정
+func SetMinIOConfig(cfg MinIOConfig) {
+	defaultMinIOConfigMu.Lock()
+	defer defaultMinIOConfigMu.Unlock()
+	defaultMinIOConfig = &cfg
+}
+
+// DefaultMinIOConfig - 기본 MinIO 연결 설정 (SetMinIOConfig 전이면 환경 변수에서 읽음)
+func DefaultMinIOConfig() MinIOConfig {
+	defaultMinIOConfigMu.RLock()
+	cfg := defaultMinIOConfig
+	defaultMinIOConfigMu.RUnlock()
+	if cfg != nil {
+		return *cfg
+	}
+	return MinIOConfigFromEnv()
+}
+
+// ResolveMinIOConfig - 프로비저닝의 minio_server(없으면 기본 설정)에 접근 키를 채운 연결 설정
+func ResolveMinIOConfig(server *MinIOConfig) MinIOConfig {
+	if server == nil {
+		return DefaultMinIOConfig()
+	}
+	return server.withCredentials()
+}
+
+// withCredentials - access_key_env/secret_key_env(기본 MINIO_ROOT_USER/MINIO_ROOT_PASSWORD)에서 접근 키를 읽은 사본
+func (c MinIOConfig) withCredentials() MinIOConfig {
+	c.AccessKeyID = os.Getenv(c.accessKeyEnv())
+	c.SecretAccessKey = os.Getenv(c.secretKeyEnv())
+	return c
+}
+
+func (c MinIOConfig) accessKeyEnv() string {
+	if c.AccessKeyEnv != "" {
+		return c.AccessKeyEnv
+	}
+	return DefaultMinIOAccessKeyEnv
+}
+
+func (c MinIOConfig) secretKeyEnv() string {
+	if c.SecretKeyEnv != "" {
+		return c.SecretKeyEnv
+	}
+	return DefaultMinIOSecretKeyEnv
+}
+
+// URL - 로그/trace에 표시할 MinIO 주소 (http://localhost:9000)
+func (c MinIOConfig) URL() string {
+	if c.UseSSL {
+		return "https://" + c.Endpoint
+	}
+	return "http://" + c.Endpoint
+}
+
+// clientKey - 같은 서버/접근 키면 클라이언트를 재사용하기 위한 키
+func (c MinIOConfig) clientKey() string {
+	return strings.Join([]string{c.URL(), c.Region, c.CAFile, c.AccessKeyID, c.SecretAccessKey}, "|")
+}
+
+// IsMinIOUnavailable - MinIO 서버에 연결할 수 없거나 서버 오류(5xx)로 크기를 조회하지 못한 경우
+// 환경 변수 미설정, 잘못된 경로, 객체 없음 등은 false (호출자가 기본 티어로 진행)
+func IsMinIOUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var resp minio.ErrorResponse
+	if errors.As(err, &resp) {
+		return resp.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// MinIOClient - 연결 설정에 해당하는 MinIO 클라이언트 (처음 요청 시 생성하고 이후 재사용)
+// 생성에 실패하면 캐시하지 않으므로 CA 파일/환경 변수를 고친 뒤 다음 요청에서 다시 시도함
+func MinIOClient(cfg MinIOConfig) (*minio.Client, error) {
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("MinIO 환경 변수 설정 안됨 (%s, %s)", cfg.accessKeyEnv(), cfg.secretKeyEnv())
+	}
+
+	key := cfg.clientKey()
+	minioClientsMu.Lock()
+	defer minioClientsMu.Unlock()
+	if client, ok := minioClients[key]; ok {
+		return client, nil
+	}
+
+	opts := &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	}
+	if cfg.CAFile != "" {
+		transport, err := minioTransport(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.Transport = transport
+	}
+
+	client, err := minio.New(cfg.Endpoint, opts)
+	if err != nil {
+		return nil, fmt.Errorf("MinIO 클라이언트 초기화 실패 (%s): %w", cfg.URL(), err)
+	}
+	minioClients[key] = client
+	return client, nil
+}
+
+// minioTransport - 시스템 CA에 사설 CA 인증서를 추가한 HTTP transport
+func minioTransport(caFile string) (*http.Transport, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("MinIO CA 파일 읽기 실패: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("MinIO CA 파일 %s에 PEM 인증서가 없습니다", caFile)
+	}
+
+	transport, err := minio.DefaultTransport(true)
+	if err != nil {
+		return nil, fmt.Errorf("MinIO transport 생성 실패: %w", err)
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return transport, nil
+}
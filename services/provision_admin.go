@@ -0,0 +1,181 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrProvisionExists - 생성하려는 provision_id가 이미 있음
+	ErrProvisionExists = errors.New("이미 존재하는 provision_id입니다")
+	// ErrProvisionNotFound - 변경하려는 provision_id가 없음
+	ErrProvisionNotFound = errors.New("provision_id를 찾을 수 없습니다")
+	// ErrPreconditionFailed - If-Match가 현재 ETag와 다름 (다른 사용자가 먼저 변경)
+	ErrPreconditionFailed = errors.New("If-Match가 현재 ETag와 일치하지 않습니다")
+	// ErrConfigReadOnly - 현재 설정 출처는 API로 변경할 수 없음 (CONFIG_SOURCE=crd 등)
+	ErrConfigReadOnly = errors.New("현재 설정 출처는 API로 변경할 수 없습니다")
+)
+
+// ConfigInvalidError - 변경 결과가 설정 검증에 실패
+type ConfigInvalidError struct {
+	Issues ConfigIssues
+}
+
+func (e *ConfigInvalidError) Error() string {
+	if err := e.Issues.Err(); err != nil {
+		return err.Error()
+	}
+	return "config 검증 실패"
+}
+
+// ProvisionETag - 프로비저닝 설정 내용의 ETag (If-Match 비교용, 따옴표 포함)
+func ProvisionETag(spec *ConfigSpec) string {
+	data, _ := json.Marshal(spec)
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// MatchETag - If-Match 헤더 값이 현재 ETag와 일치하는지 확인 ("*" 또는 쉼표로 구분된 목록 허용)
+func MatchETag(ifMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeConfigSpec - 요청 본문을 config.json과 같은 규칙(알 수 없는 필드 거부)으로 디코딩
+func DecodeConfigSpec(data []byte) (*ConfigSpec, ConfigIssues) {
+	var spec ConfigSpec
+	if err := decodeStrict(data, &spec); err != nil {
+		return nil, ConfigIssues{jsonErrorIssue(withDecodePath("$", err))}
+	}
+	return &spec, nil
+}
+
+// CreateProvision - 프로비저닝 추가
+// 반환되는 issues는 추가한 프로비저닝 기준 경로 (예: $.resource_calculation.tiers[0].queue)
+func CreateProvision(author string, spec *ConfigSpec) (*ConfigSpec, ConfigIssues, error) {
+	change := ConfigChange{Source: ConfigChangeAdmin, Author: author, Message: "create " + spec.ProvisionID}
+	return updateProvision(change, func(config *Config) (int, error) {
+		if _, err := FindProvisionConfig(config, spec.ProvisionID); err == nil {
+			return 0, ErrProvisionExists
+		}
+		config.ConfigSpecs = append(config.ConfigSpecs, *spec)
+		return len(config.ConfigSpecs) - 1, nil
+	})
+}
+
+// ReplaceProvision - 프로비저닝 설정 교체 (ifMatch가 현재 ETag와 일치해야 함)
+func ReplaceProvision(author, provisionID, ifMatch string, spec *ConfigSpec) (*ConfigSpec, ConfigIssues, error) {
+	change := ConfigChange{Source: ConfigChangeAdmin, Author: author, Message: "update " + provisionID}
+	return updateProvision(change, func(config *Config) (int, error) {
+		index, err := matchProvision(config, provisionID, ifMatch)
+		if err != nil {
+			return 0, err
+		}
+		config.ConfigSpecs[index] = *spec
+		return index, nil
+	})
+}
+
+// DisableProvision - 프로비저닝 비활성화 (enabled=false, ifMatch가 비어 있으면 ETag를 확인하지 않음)
+func DisableProvision(author, provisionID, ifMatch string) (*ConfigSpec, ConfigIssues, error) {
+	change := ConfigChange{Source: ConfigChangeAdmin, Author: author, Message: "disable " + provisionID}
+	return updateProvision(change, func(config *Config) (int, error) {
+		index, err := matchProvision(config, provisionID, ifMatch)
+		if err != nil {
+			return 0, err
+		}
+		config.ConfigSpecs[index].Enabled = false
+		return index, nil
+	})
+}
+
+// matchProvision - 변경 대상 인덱스 조회 및 If-Match 확인
+func matchProvision(config *Config, provisionID, ifMatch string) (int, error) {
+	for i := range config.ConfigSpecs {
+		if config.ConfigSpecs[i].ProvisionID != provisionID {
+			continue
+		}
+		if ifMatch != "" && !MatchETag(ifMatch, ProvisionETag(&config.ConfigSpecs[i])) {
+			return 0, ErrPreconditionFailed
+		}
+		return i, nil
+	}
+	return 0, ErrProvisionNotFound
+}
+
+// updateProvision - 현재 설정 출처에 변경을 적용하고 변경된 프로비저닝 반환
+func updateProvision(change ConfigChange, mutate func(config *Config) (int, error)) (*ConfigSpec, ConfigIssues, error) {
+	writer, ok := ActiveConfigProvider().(ConfigWriter)
+	if !ok {
+		return nil, nil, ErrConfigReadOnly
+	}
+
+	index := -1
+	config, issues, err := writer.UpdateConfig(change, func(config *Config) error {
+		var err error
+		index, err = mutate(config)
+		return err
+	})
+
+	var invalidErr *ConfigInvalidError
+	if errors.As(err, &invalidErr) {
+		return nil, nil, &ConfigInvalidError{Issues: specIssues(invalidErr.Issues, index)}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &config.ConfigSpecs[index], specIssues(issues, index), nil
+}
+
+// specIssues - 전체 설정 검증 결과 중 index번째 프로비저닝의 문제만 골라 경로를 $ 기준으로 변환
+// $.config_specs[1].tiers[0] → $.tiers[0]
+func specIssues(issues ConfigIssues, index int) ConfigIssues {
+	prefix := fmt.Sprintf("$.config_specs[%d]", index)
+	var result ConfigIssues
+	for _, issue := range issues {
+		if issue.Path != prefix && !strings.HasPrefix(issue.Path, prefix+".") {
+			continue
+		}
+		issue.Path = "$" + strings.TrimPrefix(issue.Path, prefix)
+		result = append(result, issue)
+	}
+	return result
+}
+
+// RollbackConfig - 설정을 revision 시점의 내용으로 되돌림 (새 revision으로 기록됨)
+// 되돌린 내용이 현재 설정과 같으면 새 revision 없이 현재 revision 반환
+func RollbackConfig(history *ConfigHistory, author string, revision int64) (*ConfigRevision, ConfigIssues, error) {
+	writer, ok := ActiveConfigProvider().(ConfigWriter)
+	if !ok {
+		return nil, nil, ErrConfigReadOnly
+	}
+
+	_, data, err := history.Get(revision)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config, issues, err := writer.RestoreConfig(data, ConfigChange{
+		Source:  ConfigChangeRollback,
+		Author:  author,
+		Message: fmt.Sprintf("rollback to revision %d", revision),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	current, ok := history.Latest()
+	if !ok || current.Revision != config.Revision {
+		return nil, nil, fmt.Errorf("revision %d로 롤백했지만 새 revision 기록에 실패했습니다", revision)
+	}
+	return &current, issues, nil
+}
@@ -0,0 +1,541 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"service-common/metrics"
+)
+
+const (
+	// DefaultImageCheckCacheTTL - 이미지가 있는 것으로 확인된 결과의 캐시 기간
+	DefaultImageCheckCacheTTL = 10 * time.Minute
+	// DefaultImageCheckTimeout - 레지스트리 요청 하나의 제한 시간
+	DefaultImageCheckTimeout = 5 * time.Second
+
+	// imageMissingCacheTTL - 없는 것으로 확인된 결과의 캐시 기간 (push 직후 바로 반영되도록 짧게)
+	imageMissingCacheTTL = 30 * time.Second
+	// defaultRegistryTokenTTL - expires_in이 없는 토큰 응답의 유효 기간
+	defaultRegistryTokenTTL = 60 * time.Second
+	// imageCheckSweepInterval - 만료된 캐시 결과/토큰 정리 주기
+	imageCheckSweepInterval = time.Minute
+
+	// dockerHubRegistry - 레지스트리를 생략한 이미지의 레지스트리 (docker.io/library/...)
+	dockerHubRegistry = "docker.io"
+	// dockerHubEndpoint - docker.io의 실제 distribution API 주소
+	dockerHubEndpoint = "https://registry-1.docker.io"
+)
+
+const (
+	// ImageFound - 레지스트리에 manifest가 있음
+	ImageFound = "found"
+	// ImageMissing - 레지스트리에 manifest가 없음 (404, 태그/digest 없음)
+	ImageMissing = "missing"
+	// ImageCheckError - 레지스트리 연결/인증 실패 등으로 확인하지 못함
+	ImageCheckError = "error"
+)
+
+// manifestAcceptTypes - HEAD 요청에서 받을 manifest 형식 (OCI와 Docker v2 모두)
+var manifestAcceptTypes = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+// authChallengeParamPattern - WWW-Authenticate 헤더의 key="value" 항목
+var authChallengeParamPattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+var (
+	activeImageChecker   *ImageChecker
+	activeImageCheckerMu sync.RWMutex
+)
+
+// SetImageChecker - 렌더링된 CR의 image 확인에 사용할 ImageChecker 지정 (nil이면 확인하지 않음)
+func SetImageChecker(checker *ImageChecker) {
+	activeImageCheckerMu.Lock()
+	defer activeImageCheckerMu.Unlock()
+	activeImageChecker = checker
+}
+
+// ActiveImageChecker - 현재 ImageChecker (IMAGE_CHECK_REGISTRIES가 비어 있으면 nil)
+func ActiveImageChecker() *ImageChecker {
+	activeImageCheckerMu.RLock()
+	defer activeImageCheckerMu.RUnlock()
+	return activeImageChecker
+}
+
+// ImageReference - 파싱된 이미지 참조 (registry/repository:tag@digest)
+type ImageReference struct {
+	Registry   string // docker.io, harbor.example.com, localhost:5000
+	Repository string // library/spark
+	Tag        string
+	Digest     string
+}
+
+// Reference - manifest 조회에 사용할 참조 (digest가 있으면 digest 우선)
+func (r ImageReference) Reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// ParseImageReference - 이미지 문자열 파싱
+// 첫 경로 요소에 "." 또는 ":"가 있거나 localhost이면 레지스트리로 보고, 없으면 docker.io (단일 이름은 library/ 추가)
+func ParseImageReference(image string) (ImageReference, error) {
+	var ref ImageReference
+	name := strings.TrimSpace(image)
+	if name == "" {
+		return ref, fmt.Errorf("이미지가 비어 있습니다")
+	}
+
+	if at := strings.Index(name, "@"); at >= 0 {
+		name, ref.Digest = name[:at], name[at+1:]
+	}
+	if slash, colon := strings.LastIndex(name, "/"), strings.LastIndex(name, ":"); colon > slash {
+		name, ref.Tag = name[:colon], name[colon+1:]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	first, rest, found := strings.Cut(name, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry, ref.Repository = normalizeRegistry(first), rest
+	} else {
+		ref.Registry, ref.Repository = dockerHubRegistry, name
+	}
+	if ref.Registry == dockerHubRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+	if ref.Repository == "" {
+		return ref, fmt.Errorf("이미지 %q의 저장소 이름이 비어 있습니다", image)
+	}
+	return ref, nil
+}
+
+// normalizeRegistry - docker.io의 다른 이름을 docker.io로 통일
+func normalizeRegistry(host string) string {
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return dockerHubRegistry
+	}
+	return host
+}
+
+// RegistryCredential - 레지스트리 인증 정보
+type RegistryCredential struct {
+	Username string
+	Password string
+}
+
+// LoadRegistryCredentials - docker config.json(~/.docker/config.json, imagePullSecret의 .dockerconfigjson)의 auths 읽기
+func LoadRegistryCredentials(path string) (map[string]RegistryCredential, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("레지스트리 인증 파일 읽기 실패: %w", err)
+	}
+
+	var file struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("레지스트리 인증 파일 파싱 실패 (%s): %w", path, err)
+	}
+
+	creds := make(map[string]RegistryCredential, len(file.Auths))
+	for server, entry := range file.Auths {
+		cred := RegistryCredential{Username: entry.Username, Password: entry.Password}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("레지스트리 %s의 auth 값이 base64가 아닙니다: %w", server, err)
+			}
+			cred.Username, cred.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		creds[registryHostOf(server)] = cred
+	}
+	return creds, nil
+}
+
+// registryHostOf - docker config.json의 서버 키(https://index.docker.io/v1/ 등)를 레지스트리 이름으로 변환
+func registryHostOf(server string) string {
+	host := server
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return normalizeRegistry(strings.TrimSuffix(host, "/"))
+}
+
+// ImageCheckerOptions - ImageChecker 설정
+type ImageCheckerOptions struct {
+	// Registries - 확인할 레지스트리 (docker.io, harbor.example.com, http://localhost:5000)
+	// http:// 로 지정하면 TLS 없이 접속하며, 목록에 없는 레지스트리의 이미지는 확인하지 않음
+	Registries  []string
+	Credentials map[string]RegistryCredential // 레지스트리 이름 → 인증 정보
+	CacheTTL    time.Duration
+	Timeout     time.Duration
+}
+
+// ImageCheck - 이미지 하나의 확인 결과
+type ImageCheck struct {
+	Image   string `json:"image"`
+	Status  string `json:"status"` // found, missing, error
+	Message string `json:"message,omitempty"`
+}
+
+// imageCheckEntry - 캐시된 확인 결과
+type imageCheckEntry struct {
+	status  string
+	expires time.Time
+}
+
+// registryToken - 캐시된 Bearer 토큰
+type registryToken struct {
+	token   string
+	expires time.Time
+}
+
+// ImageChecker - OCI distribution v2 API로 이미지 manifest 존재 여부 확인 (HEAD /v2/<name>/manifests/<reference>)
+type ImageChecker struct {
+	endpoints   map[string]string // 레지스트리 이름 → API 주소 (https://registry-1.docker.io)
+	credentials map[string]RegistryCredential
+	ttl         time.Duration
+	client      *http.Client
+
+	mu        sync.Mutex
+	cache     map[string]imageCheckEntry // registry/repository:reference → 결과
+	tokens    map[string]registryToken   // API 주소|scope → 토큰
+	lastSweep time.Time
+}
+
+// NewImageChecker - ImageChecker 생성
+func NewImageChecker(opts ImageCheckerOptions) (*ImageChecker, error) {
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = DefaultImageCheckCacheTTL
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultImageCheckTimeout
+	}
+
+	endpoints := make(map[string]string, len(opts.Registries))
+	for _, registry := range opts.Registries {
+		scheme, host := "https", registry
+		if rest, ok := strings.CutPrefix(registry, "http://"); ok {
+			scheme, host = "http", rest
+		} else if rest, ok := strings.CutPrefix(registry, "https://"); ok {
+			host = rest
+		}
+		host = normalizeRegistry(strings.TrimSuffix(host, "/"))
+		if host == "" || strings.Contains(host, "/") {
+			return nil, fmt.Errorf("잘못된 레지스트리 %q (예: docker.io, harbor.example.com, http://localhost:5000)", registry)
+		}
+
+		endpoints[host] = scheme + "://" + host
+		if host == dockerHubRegistry {
+			endpoints[host] = dockerHubEndpoint
+		}
+	}
+
+	credentials := opts.Credentials
+	if credentials == nil {
+		credentials = map[string]RegistryCredential{}
+	}
+	return &ImageChecker{
+		endpoints:   endpoints,
+		credentials: credentials,
+		ttl:         opts.CacheTTL,
+		client:      &http.Client{Timeout: opts.Timeout},
+		cache:       make(map[string]imageCheckEntry),
+		tokens:      make(map[string]registryToken),
+		lastSweep:   time.Now(),
+	}, nil
+}
+
+// Registries - 확인 대상 레지스트리 이름
+func (c *ImageChecker) Registries() []string {
+	registries := make([]string, 0, len(c.endpoints))
+	for registry := range c.endpoints {
+		registries = append(registries, registry)
+	}
+	return registries
+}
+
+// CheckYAML - 렌더링된 CR의 모든 image를 확인 (중복 제외, 확인 대상이 아닌 레지스트리는 결과에 포함하지 않음)
+func (c *ImageChecker) CheckYAML(ctx context.Context, yamlStr string) []ImageCheck {
+	var results []ImageCheck
+	for _, image := range ImagesInYAML(yamlStr) {
+		if result, ok := c.Check(ctx, image); ok {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Check - 이미지 manifest 존재 여부 확인 (확인 대상 레지스트리가 아니면 false)
+func (c *ImageChecker) Check(ctx context.Context, image string) (ImageCheck, bool) {
+	ref, err := ParseImageReference(image)
+	if err != nil {
+		return ImageCheck{Image: image, Status: ImageCheckError, Message: err.Error()}, true
+	}
+	endpoint, ok := c.endpoints[ref.Registry]
+	if !ok {
+		return ImageCheck{}, false
+	}
+
+	key := ref.Registry + "/" + ref.Repository + ":" + ref.Reference()
+	if status, ok := c.cached(key); ok {
+		metrics.ImageChecks.WithLabelValues(ref.Registry, status, "hit").Inc()
+		return newImageCheck(image, ref, status, nil), true
+	}
+
+	status, err := c.headManifest(ctx, endpoint, ref)
+	metrics.ImageChecks.WithLabelValues(ref.Registry, status, "miss").Inc()
+	if err == nil {
+		c.store(key, status)
+	}
+	return newImageCheck(image, ref, status, err), true
+}
+
+// newImageCheck - 상태별 메시지를 포함한 확인 결과 생성
+func newImageCheck(image string, ref ImageReference, status string, err error) ImageCheck {
+	result := ImageCheck{Image: image, Status: status}
+	switch {
+	case err != nil:
+		result.Message = fmt.Sprintf("레지스트리 %s 확인 실패: %v", ref.Registry, err)
+	case status == ImageMissing:
+		result.Message = fmt.Sprintf("레지스트리 %s에 %s:%s가 없습니다", ref.Registry, ref.Repository, ref.Reference())
+	}
+	return result
+}
+
+// cached - 유효한 캐시 결과 조회
+func (c *ImageChecker) cached(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[key]
+	if !ok || time.Now().After(entry.expires) {
+		return "", false
+	}
+	return entry.status, true
+}
+
+// store - 확인 결과 캐시 (없음은 짧게 보관, 저장할 때 만료된 결과/토큰 정리)
+func (c *ImageChecker) store(key, status string) {
+	ttl := c.ttl
+	if status == ImageMissing && ttl > imageMissingCacheTTL {
+		ttl = imageMissingCacheTTL
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	c.sweepLocked(now)
+	c.cache[key] = imageCheckEntry{status: status, expires: now.Add(ttl)}
+}
+
+// sweepLocked - 만료된 캐시 결과와 토큰 정리 (mu 보유 상태에서 호출)
+// 캐시는 최근 TTL 동안 확인한 이미지 수만큼만 유지됨
+func (c *ImageChecker) sweepLocked(now time.Time) {
+	if now.Sub(c.lastSweep) < imageCheckSweepInterval {
+		return
+	}
+	for key, entry := range c.cache {
+		if now.After(entry.expires) {
+			delete(c.cache, key)
+		}
+	}
+	for key, token := range c.tokens {
+		if now.After(token.expires) {
+			delete(c.tokens, key)
+		}
+	}
+	c.lastSweep = now
+}
+
+// headManifest - manifest HEAD 요청 (401이면 WWW-Authenticate에 따라 Bearer 토큰 또는 Basic 인증으로 한 번 재시도)
+func (c *ImageChecker) headManifest(ctx context.Context, endpoint string, ref ImageReference) (string, error) {
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", endpoint, ref.Repository, ref.Reference())
+	scope := "repository:" + ref.Repository + ":pull"
+
+	resp, err := c.doHead(ctx, manifestURL, c.cachedAuthorization(endpoint, scope))
+	if err != nil {
+		return ImageCheckError, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err := c.authorize(ctx, ref.Registry, resp.Header.Get("WWW-Authenticate"), scope)
+		if err != nil {
+			return ImageCheckError, err
+		}
+		if resp, err = c.doHead(ctx, manifestURL, authorization); err != nil {
+			return ImageCheckError, err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return ImageFound, nil
+	case http.StatusNotFound:
+		return ImageMissing, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// Docker Hub는 없는 저장소에도 401을 반환하므로 없음으로 단정하지 않음
+		return ImageCheckError, fmt.Errorf("권한이 없거나 저장소가 없습니다 (HTTP %d)", resp.StatusCode)
+	default:
+		return ImageCheckError, fmt.Errorf("예상하지 못한 응답 HTTP %d", resp.StatusCode)
+	}
+}
+
+// doHead - HEAD 요청 (본문은 읽지 않음)
+func (c *ImageChecker) doHead(ctx context.Context, manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", manifestAcceptTypes)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// cachedAuthorization - 같은 레지스트리/scope로 받은 유효한 토큰이 있으면 Authorization 헤더 값
+func (c *ImageChecker) cachedAuthorization(endpoint, scope string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	token, ok := c.tokens[endpoint+"|"+scope]
+	if !ok || time.Now().After(token.expires) {
+		return ""
+	}
+	return "Bearer " + token.token
+}
+
+// authorize - 인증 challenge에 맞는 Authorization 헤더 값 생성
+func (c *ImageChecker) authorize(ctx context.Context, registry, challenge, scope string) (string, error) {
+	scheme, paramText, _ := strings.Cut(challenge, " ")
+	params := map[string]string{}
+	for _, match := range authChallengeParamPattern.FindAllStringSubmatch(paramText, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+	cred, hasCred := c.credentials[registry]
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCred {
+			return "", fmt.Errorf("레지스트리 %s는 인증이 필요합니다 (IMAGE_CHECK_AUTH_FILE)", registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(cred.Username+":"+cred.Password)), nil
+	case "bearer":
+		if params["scope"] == "" {
+			params["scope"] = scope
+		}
+		token, err := c.fetchToken(ctx, params, cred, hasCred)
+		if err != nil {
+			return "", err
+		}
+		c.mu.Lock()
+		c.tokens[c.endpoints[registry]+"|"+scope] = token
+		c.mu.Unlock()
+		return "Bearer " + token.token, nil
+	default:
+		return "", fmt.Errorf("지원하지 않는 인증 방식: %q", challenge)
+	}
+}
+
+// fetchToken - Bearer challenge의 realm에서 pull 토큰 발급 (인증 정보가 없으면 익명)
+func (c *ImageChecker) fetchToken(ctx context.Context, params map[string]string, cred RegistryCredential, hasCred bool) (registryToken, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return registryToken{}, fmt.Errorf("Bearer challenge에 realm이 없습니다")
+	}
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return registryToken{}, fmt.Errorf("잘못된 토큰 realm %q: %w", realm, err)
+	}
+	query := tokenURL.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", params["scope"])
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return registryToken{}, err
+	}
+	if hasCred {
+		req.SetBasicAuth(cred.Username, cred.Password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return registryToken{}, fmt.Errorf("토큰 발급 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return registryToken{}, fmt.Errorf("토큰 발급 실패 (HTTP %d)", resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return registryToken{}, fmt.Errorf("토큰 응답 파싱 실패: %w", err)
+	}
+	token := body.Token
+	if token == "" {
+		token = body.AccessToken
+	}
+	if token == "" {
+		return registryToken{}, fmt.Errorf("토큰 응답에 token이 없습니다")
+	}
+
+	ttl := defaultRegistryTokenTTL
+	if body.ExpiresIn > 0 {
+		ttl = time.Duration(body.ExpiresIn) * time.Second
+	}
+	// 만료 직전 토큰으로 요청하지 않도록 여유를 둠
+	return registryToken{token: token, expires: time.Now().Add(ttl * 9 / 10)}, nil
+}
+
+// ImagesInYAML - YAML의 모든 image 값 (등장 순서, 중복 제외)
+func ImagesInYAML(yamlStr string) []string {
+	var images []string
+	seen := map[string]bool{}
+	for _, line := range strings.Split(yamlStr, "\n") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), "image:")
+		if !ok {
+			continue
+		}
+		image := strings.Trim(strings.TrimSpace(value), `"'`)
+		if image != "" && !seen[image] {
+			seen[image] = true
+			images = append(images, image)
+		}
+	}
+	return images
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRegistry - Bearer 토큰 인증을 요구하는 distribution v2 레지스트리
+type fakeRegistry struct {
+	server    *httptest.Server
+	manifests map[string]bool // repository:reference → 존재 여부
+
+	mu            sync.Mutex
+	manifestHeads int
+	tokenRequests int
+	scopes        []string
+}
+
+const fakeRegistryToken = "test-token"
+
+func newFakeRegistry(t *testing.T, manifests ...string) *fakeRegistry {
+	t.Helper()
+	r := &fakeRegistry{manifests: map[string]bool{}}
+	for _, manifest := range manifests {
+		r.manifests[manifest] = true
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		r.tokenRequests++
+		r.scopes = append(r.scopes, req.URL.Query().Get("scope"))
+		r.mu.Unlock()
+		if req.URL.Query().Get("service") != "fake-registry" {
+			http.Error(w, "unknown service", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"token": fakeRegistryToken, "expires_in": 300})
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer "+fakeRegistryToken {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake-registry"`, r.server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		name, reference, ok := strings.Cut(strings.TrimPrefix(req.URL.Path, "/v2/"), "/manifests/")
+		if req.Method != http.MethodHead || !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.mu.Lock()
+		r.manifestHeads++
+		r.mu.Unlock()
+		if !r.manifests[name+":"+reference] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r.server = httptest.NewServer(mux)
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+// host - 이미지 참조에 사용할 레지스트리 이름 (127.0.0.1:port)
+func (r *fakeRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+func (r *fakeRegistry) counts() (manifestHeads, tokenRequests int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.manifestHeads, r.tokenRequests
+}
+
+func newTestImageChecker(t *testing.T, r *fakeRegistry, ttl time.Duration) *ImageChecker {
+	t.Helper()
+	checker, err := NewImageChecker(ImageCheckerOptions{Registries: []string{r.server.URL}, CacheTTL: ttl})
+	if err != nil {
+		t.Fatalf("NewImageChecker: %v", err)
+	}
+	return checker
+}
+
+func TestImageCheckerCheck(t *testing.T) {
+	registry := newFakeRegistry(t, "team/spark:4.13.1", "team/spark:sha256:abc")
+	checker := newTestImageChecker(t, registry, time.Minute)
+
+	tests := []struct {
+		name       string
+		image      string
+		wantStatus string
+		wantCheck  bool
+	}{
+		{name: "tag found", image: registry.host() + "/team/spark:4.13.1", wantStatus: ImageFound, wantCheck: true},
+		{name: "digest found", image: registry.host() + "/team/spark:4.13.1@sha256:abc", wantStatus: ImageFound, wantCheck: true},
+		{name: "tag missing", image: registry.host() + "/team/spark:4.14.1", wantStatus: ImageMissing, wantCheck: true},
+		{name: "repository missing", image: registry.host() + "/team/other:4.13.1", wantStatus: ImageMissing, wantCheck: true},
+		{name: "unchecked registry", image: "harbor.example.com/team/spark:4.13.1", wantCheck: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, checked := checker.Check(context.Background(), tt.image)
+			if checked != tt.wantCheck {
+				t.Fatalf("checked = %v, want %v", checked, tt.wantCheck)
+			}
+			if checked && result.Status != tt.wantStatus {
+				t.Errorf("status = %s (%s), want %s", result.Status, result.Message, tt.wantStatus)
+			}
+			if tt.wantStatus == ImageMissing && result.Message == "" {
+				t.Error("missing image has no message")
+			}
+		})
+	}
+}
+
+func TestImageCheckerTokenChallenge(t *testing.T) {
+	registry := newFakeRegistry(t, "team/spark:4.13.1", "team/spark:4.14.1")
+	checker := newTestImageChecker(t, registry, time.Minute)
+	ctx := context.Background()
+
+	// 첫 요청: 401 → 토큰 발급 → 재시도
+	if result, _ := checker.Check(ctx, registry.host()+"/team/spark:4.13.1"); result.Status != ImageFound {
+		t.Fatalf("status = %s (%s), want found", result.Status, result.Message)
+	}
+	if _, tokens := registry.counts(); tokens != 1 {
+		t.Fatalf("token requests = %d, want 1", tokens)
+	}
+	registry.mu.Lock()
+	scope := registry.scopes[0]
+	registry.mu.Unlock()
+	if scope != "repository:team/spark:pull" {
+		t.Errorf("token scope = %q, want repository:team/spark:pull", scope)
+	}
+
+	// 같은 저장소의 다른 태그는 캐시된 토큰 사용
+	if result, _ := checker.Check(ctx, registry.host()+"/team/spark:4.14.1"); result.Status != ImageFound {
+		t.Fatalf("status = %s (%s), want found", result.Status, result.Message)
+	}
+	if _, tokens := registry.counts(); tokens != 1 {
+		t.Errorf("token requests = %d, want cached token", tokens)
+	}
+}
+
+func TestImageCheckerCacheTTL(t *testing.T) {
+	registry := newFakeRegistry(t, "team/spark:4.13.1")
+	checker := newTestImageChecker(t, registry, 50*time.Millisecond)
+	ctx := context.Background()
+	image := registry.host() + "/team/spark:4.13.1"
+
+	checker.Check(ctx, image)
+	checker.Check(ctx, image)
+	if heads, _ := registry.counts(); heads != 1 {
+		t.Fatalf("manifest requests within TTL = %d, want 1", heads)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if result, _ := checker.Check(ctx, image); result.Status != ImageFound {
+		t.Fatalf("status = %s, want found", result.Status)
+	}
+	if heads, _ := registry.counts(); heads != 2 {
+		t.Errorf("manifest requests after TTL = %d, want 2", heads)
+	}
+}
+
+func TestImageCheckerMissingCachedShortly(t *testing.T) {
+	registry := newFakeRegistry(t)
+	checker := newTestImageChecker(t, registry, time.Hour)
+	key := registry.host() + "/team/spark:4.13.1"
+
+	checker.Check(context.Background(), key)
+	checker.mu.Lock()
+	entry := checker.cache[key]
+	checker.mu.Unlock()
+	if entry.status != ImageMissing || time.Until(entry.expires) > imageMissingCacheTTL {
+		t.Errorf("missing entry = %+v, want status missing cached for at most %s", entry, imageMissingCacheTTL)
+	}
+}
+
+func TestImageCheckerErrorsNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	checker, err := NewImageChecker(ImageCheckerOptions{Registries: []string{server.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	image := strings.TrimPrefix(server.URL, "http://") + "/team/spark:4.13.1"
+	result, _ := checker.Check(context.Background(), image)
+	if result.Status != ImageCheckError {
+		t.Fatalf("status = %s, want error", result.Status)
+	}
+	if _, cached := checker.cached(image); cached {
+		t.Error("registry errors must not be cached")
+	}
+}
+
+func TestImageCheckerSweepsExpiredEntries(t *testing.T) {
+	registry := newFakeRegistry(t)
+	checker := newTestImageChecker(t, registry, time.Minute)
+
+	checker.mu.Lock()
+	checker.cache["old/image:1"] = imageCheckEntry{status: ImageFound, expires: time.Now().Add(-time.Second)}
+	checker.tokens["old|scope"] = registryToken{token: "t", expires: time.Now().Add(-time.Second)}
+	checker.lastSweep = time.Now().Add(-2 * imageCheckSweepInterval)
+	checker.mu.Unlock()
+
+	checker.store("new/image:1", ImageFound)
+
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+	if _, ok := checker.cache["old/image:1"]; ok {
+		t.Error("expired result was not pruned")
+	}
+	if _, ok := checker.tokens["old|scope"]; ok {
+		t.Error("expired token was not pruned")
+	}
+	if _, ok := checker.cache["new/image:1"]; !ok {
+		t.Error("new result was not stored")
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image string
+		want  ImageReference
+	}{
+		{image: "spark", want: ImageReference{Registry: "docker.io", Repository: "library/spark", Tag: "latest"}},
+		{image: "apache/spark:3.5.0", want: ImageReference{Registry: "docker.io", Repository: "apache/spark", Tag: "3.5.0"}},
+		{image: "localhost:5000/spark:4.13.1", want: ImageReference{Registry: "localhost:5000", Repository: "spark", Tag: "4.13.1"}},
+		{image: "harbor.example.com/team/spark@sha256:abc", want: ImageReference{Registry: "harbor.example.com", Repository: "team/spark", Digest: "sha256:abc"}},
+		{image: "index.docker.io/library/spark:1", want: ImageReference{Registry: "docker.io", Repository: "library/spark", Tag: "1"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseImageReference(tt.image)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseImageReference(%q) = %+v, %v, want %+v", tt.image, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+
+	"service-common/metrics"
+)
+
+const (
+	// DefaultSizeCacheTTL - 캐시된 크기를 MinIO에 다시 확인하지 않고 사용하는 기간
+	DefaultSizeCacheTTL = time.Minute
+	// DefaultSizeCacheMaxEntries - 캐시 최대 항목 수 (초과하면 가장 오래 사용하지 않은 항목 제거)
+	DefaultSizeCacheMaxEntries = 10000
+
+	sizeCacheObject = "object"
+	sizeCacheFolder = "folder"
+)
+
+const (
+	// SizeCacheHit - TTL 안의 캐시 값 사용
+	SizeCacheHit = "hit"
+	// SizeCacheMiss - 캐시에 없거나 만료(폴더)/변경(객체)되어 MinIO에서 조회
+	SizeCacheMiss = "miss"
+	// SizeCacheRevalidated - TTL이 지난 객체를 ETag로 확인해 변경되지 않아 캐시 값 사용
+	SizeCacheRevalidated = "revalidated"
+	// SizeCacheBypass - nocache=true로 캐시를 읽지 않고 조회 (결과는 캐시에 저장)
+	SizeCacheBypass = "bypass"
+)
+
+// errObjectNotModified - If-None-Match 조회에서 객체 ETag가 같음 (304)
+var errObjectNotModified = errors.New("MinIO 객체가 변경되지 않았습니다")
+
+var (
+	activeSizeCache   *SizeCache
+	activeSizeCacheMu sync.RWMutex
+)
+
+// SetSizeCache - 리소스 계산에 사용할 크기 캐시 지정 (nil이면 매번 MinIO 조회)
+func SetSizeCache(cache *SizeCache) {
+	activeSizeCacheMu.Lock()
+	defer activeSizeCacheMu.Unlock()
+	activeSizeCache = cache
+}
+
+// ActiveSizeCache - 현재 크기 캐시 (SIZE_CACHE_TTL=0이면 nil)
+func ActiveSizeCache() *SizeCache {
+	activeSizeCacheMu.RLock()
+	defer activeSizeCacheMu.RUnlock()
+	return activeSizeCache
+}
+
+// SizeCache - MinIO 폴더 크기와 객체 메타데이터 캐시 (서버 주소 + 경로 기준)
+// 폴더는 TTL 동안만 사용하고, 객체는 TTL이 지나면 ETag(If-None-Match)로 변경 여부를 확인해 그대로면 계속 사용
+// 조회 오류는 캐시하지 않음. nil SizeCache는 캐시 없이 매번 조회함
+type SizeCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // 앞쪽이 최근 사용 (*sizeCacheEntry)
+}
+
+// sizeCacheEntry - 캐시 항목 (객체는 metadata, 폴더는 size/count)
+type sizeCacheEntry struct {
+	key      string
+	storedAt time.Time
+	metadata *MinIOMetadata
+	size     int64
+	count    int
+}
+
+// NewSizeCache - 크기 캐시 생성 (0 이하 값은 기본값)
+func NewSizeCache(ttl time.Duration, maxEntries int) *SizeCache {
+	if ttl <= 0 {
+		ttl = DefaultSizeCacheTTL
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultSizeCacheMaxEntries
+	}
+	return &SizeCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// TTL - 캐시 유효 기간
+func (c *SizeCache) TTL() time.Duration {
+	return c.ttl
+}
+
+// MaxEntries - 최대 항목 수
+func (c *SizeCache) MaxEntries() int {
+	return c.maxEntries
+}
+
+// ObjectMetadata - 단일 객체 메타데이터 (캐시 결과: hit, miss, revalidated, bypass, 캐시가 없으면 빈 문자열)
+func (c *SizeCache) ObjectMetadata(cfg MinIOConfig, minioPath string, bypass bool) (*MinIOMetadata, string, error) {
+	if c == nil {
+		metadata, err := getMinIOMetadata(cfg, minioPath, "")
+		return metadata, "", err
+	}
+
+	key := sizeCacheKey(sizeCacheObject, cfg, minioPath)
+	result, etag := SizeCacheMiss, ""
+	if bypass {
+		result = SizeCacheBypass
+	} else if entry, fresh := c.lookup(key); entry != nil {
+		if fresh {
+			c.record(sizeCacheObject, SizeCacheHit)
+			return entry.metadata, SizeCacheHit, nil
+		}
+		etag = entry.metadata.ETag
+	}
+
+	metadata, err := getMinIOMetadata(cfg, minioPath, etag)
+	if errors.Is(err, errObjectNotModified) {
+		if entry := c.refresh(key); entry != nil {
+			c.record(sizeCacheObject, SizeCacheRevalidated)
+			return entry.metadata, SizeCacheRevalidated, nil
+		}
+		// 확인하는 동안 다른 요청이 항목을 제거한 경우 다시 조회
+		metadata, err = getMinIOMetadata(cfg, minioPath, "")
+	}
+	if err != nil {
+		c.remove(key)
+	} else {
+		c.store(&sizeCacheEntry{key: key, metadata: metadata})
+	}
+	c.record(sizeCacheObject, result)
+	return metadata, result, err
+}
+
+// FolderSize - 폴더(접두사) 크기 합계와 오브젝트 수 (캐시 결과는 ObjectMetadata와 같음, revalidated 없음)
+func (c *SizeCache) FolderSize(cfg MinIOConfig, minioPath string, bypass bool) (int64, int, string, error) {
+	if c == nil {
+		size, count, err := getMinioFolderSize(cfg, minioPath)
+		return size, count, "", err
+	}
+
+	key := sizeCacheKey(sizeCacheFolder, cfg, minioPath)
+	result := SizeCacheMiss
+	if bypass {
+		result = SizeCacheBypass
+	} else if entry, fresh := c.lookup(key); fresh {
+		c.record(sizeCacheFolder, SizeCacheHit)
+		return entry.size, entry.count, SizeCacheHit, nil
+	}
+
+	size, count, err := getMinioFolderSize(cfg, minioPath)
+	if err != nil {
+		c.remove(key)
+	} else {
+		c.store(&sizeCacheEntry{key: key, size: size, count: count})
+	}
+	c.record(sizeCacheFolder, result)
+	return size, count, result, err
+}
+
+// sizeCacheKey - 종류/서버/경로별 캐시 키 (같은 경로라도 다른 MinIO 서버면 다른 항목)
+func sizeCacheKey(kind string, cfg MinIOConfig, minioPath string) string {
+	return kind + "|" + cfg.URL() + "|" + minioPath
+}
+
+// lookup - 캐시 항목과 TTL 안인지 여부 (최근 사용으로 이동)
+func (c *SizeCache) lookup(key string) (*sizeCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	entry := element.Value.(*sizeCacheEntry)
+	return entry, time.Since(entry.storedAt) < c.ttl
+}
+
+// refresh - ETag 확인으로 변경되지 않은 항목의 TTL 갱신
+func (c *SizeCache) refresh(key string) *sizeCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*sizeCacheEntry)
+	entry.storedAt = time.Now()
+	return entry
+}
+
+// store - 항목 저장 (최대 항목 수를 넘으면 가장 오래 사용하지 않은 항목 제거)
+func (c *SizeCache) store(entry *sizeCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry.storedAt = time.Now()
+	if element, ok := c.entries[entry.key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*sizeCacheEntry).key)
+	}
+	metrics.SizeCacheEntries.Set(float64(c.lru.Len()))
+}
+
+// remove - 조회에 실패한 항목 제거 (삭제된 객체를 캐시 값으로 계속 사용하지 않도록)
+func (c *SizeCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+		metrics.SizeCacheEntries.Set(float64(c.lru.Len()))
+	}
+}
+
+// record - 캐시 조회 결과 메트릭 기록
+func (c *SizeCache) record(kind, result string) {
+	metrics.SizeCacheRequests.WithLabelValues(kind, result).Inc()
+}
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3Object - fakeS3에 저장된 객체
+type fakeS3Object struct {
+	size int64
+	etag string
+}
+
+// fakeS3 - StatObject(HEAD)와 ListObjectsV2만 지원하는 S3 호환 서버 (path-style, 버킷은 하나)
+type fakeS3 struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	objects  map[string]fakeS3Object // bucket/key → 객체
+	heads    int
+	notMod   int
+	listings int
+}
+
+func newFakeS3(t *testing.T) *fakeS3 {
+	t.Helper()
+	s := &fakeS3{objects: map[string]fakeS3Object{}}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *fakeS3) put(path string, size int64, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[path] = fakeS3Object{size: size, etag: etag}
+}
+
+func (s *fakeS3) delete(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, path)
+}
+
+// requests - HEAD(304 포함), 304 응답, 목록 요청 수
+func (s *fakeS3) requests() (heads, notModified, listings int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.heads, s.notMod, s.listings
+}
+
+// config - fakeS3에 접속하는 MinIOConfig (region을 지정해 bucket location 조회 생략)
+func (s *fakeS3) config() MinIOConfig {
+	return MinIOConfig{
+		Endpoint:        strings.TrimPrefix(s.server.URL, "http://"),
+		AccessKeyID:     "test",
+		SecretAccessKey: "test-secret",
+		Region:          "us-east-1",
+	}
+}
+
+func (s *fakeS3) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodHead && key != "":
+		s.heads++
+		object, ok := s.objects[bucket+"/"+key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		etag := `"` + object.etag + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			s.notMod++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Length", strconv.FormatInt(object.size, 10))
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Last-Modified", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodGet && key == "" && r.URL.Query().Get("list-type") == "2":
+		s.listings++
+		type content struct {
+			Key          string
+			Size         int64
+			ETag         string
+			LastModified string
+		}
+		result := struct {
+			XMLName     xml.Name `xml:"ListBucketResult"`
+			Name        string
+			Prefix      string
+			KeyCount    int
+			MaxKeys     int
+			IsTruncated bool
+			Contents    []content
+		}{Name: bucket, Prefix: r.URL.Query().Get("prefix"), MaxKeys: 1000}
+		for path, object := range s.objects {
+			objectKey, ok := strings.CutPrefix(path, bucket+"/")
+			if ok && strings.HasPrefix(objectKey, result.Prefix) {
+				result.Contents = append(result.Contents, content{
+					Key: objectKey, Size: object.size, ETag: `"` + object.etag + `"`, LastModified: "2026-01-01T00:00:00.000Z",
+				})
+			}
+		}
+		result.KeyCount = len(result.Contents)
+		w.Header().Set("Content-Type", "application/xml")
+		xml.NewEncoder(w).Encode(result)
+
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func TestSizeCacheObjectTTLAndRevalidation(t *testing.T) {
+	s3 := newFakeS3(t)
+	s3.put("bucket/data/a.parquet", 100, "v1")
+	cache := NewSizeCache(50*time.Millisecond, 10)
+	cfg := s3.config()
+
+	steps := []struct {
+		name       string
+		prepare    func()
+		wantResult string
+		wantSize   int64
+		wantHeads  int
+		wantNotMod int
+	}{
+		{name: "first lookup", wantResult: SizeCacheMiss, wantSize: 100, wantHeads: 1},
+		{name: "within TTL", wantResult: SizeCacheHit, wantSize: 100, wantHeads: 1},
+		{name: "expired but unchanged", prepare: func() { time.Sleep(60 * time.Millisecond) },
+			wantResult: SizeCacheRevalidated, wantSize: 100, wantHeads: 2, wantNotMod: 1},
+		{name: "revalidation renews TTL", wantResult: SizeCacheHit, wantSize: 100, wantHeads: 2, wantNotMod: 1},
+		{name: "expired and changed", prepare: func() {
+			s3.put("bucket/data/a.parquet", 300, "v2")
+			time.Sleep(60 * time.Millisecond)
+		}, wantResult: SizeCacheMiss, wantSize: 300, wantHeads: 3, wantNotMod: 1},
+		{name: "bypass always queries", prepare: func() {}, wantResult: SizeCacheBypass, wantSize: 300, wantHeads: 4, wantNotMod: 1},
+	}
+
+	for _, step := range steps {
+		if step.prepare != nil {
+			step.prepare()
+		}
+		metadata, result, err := cache.ObjectMetadata(cfg, "bucket/data/a.parquet", step.wantResult == SizeCacheBypass)
+		if err != nil {
+			t.Fatalf("%s: ObjectMetadata error = %v", step.name, err)
+		}
+		if result != step.wantResult || metadata.Size != step.wantSize {
+			t.Errorf("%s: result = %s size %d, want %s size %d", step.name, result, metadata.Size, step.wantResult, step.wantSize)
+		}
+		if heads, notMod, _ := s3.requests(); heads != step.wantHeads || notMod != step.wantNotMod {
+			t.Errorf("%s: HEAD requests = %d (304: %d), want %d (304: %d)", step.name, heads, notMod, step.wantHeads, step.wantNotMod)
+		}
+	}
+}
+
+func TestSizeCacheObjectErrorRemovesEntry(t *testing.T) {
+	s3 := newFakeS3(t)
+	s3.put("bucket/data/a.parquet", 100, "v1")
+	cache := NewSizeCache(50*time.Millisecond, 10)
+	cfg := s3.config()
+
+	if _, _, err := cache.ObjectMetadata(cfg, "bucket/data/a.parquet", false); err != nil {
+		t.Fatal(err)
+	}
+	s3.delete("bucket/data/a.parquet")
+	time.Sleep(60 * time.Millisecond)
+
+	if _, _, err := cache.ObjectMetadata(cfg, "bucket/data/a.parquet", false); err == nil {
+		t.Fatal("deleted object must not be served from cache")
+	}
+	if entry, _ := cache.lookup(sizeCacheKey(sizeCacheObject, cfg, "bucket/data/a.parquet")); entry != nil {
+		t.Errorf("failed lookup left entry %+v in cache", entry)
+	}
+}
+
+func TestSizeCacheFolderTTL(t *testing.T) {
+	s3 := newFakeS3(t)
+	s3.put("bucket/svc/part-0", 100, "a")
+	s3.put("bucket/svc/part-1", 200, "b")
+	s3.put("bucket/other/part-0", 1000, "c")
+	cache := NewSizeCache(50*time.Millisecond, 10)
+	cfg := s3.config()
+
+	steps := []struct {
+		name         string
+		prepare      func()
+		wantResult   string
+		wantSize     int64
+		wantCount    int
+		wantListings int
+	}{
+		{name: "first lookup", wantResult: SizeCacheMiss, wantSize: 300, wantCount: 2, wantListings: 1},
+		{name: "within TTL", prepare: func() { s3.put("bucket/svc/part-2", 50, "d") },
+			wantResult: SizeCacheHit, wantSize: 300, wantCount: 2, wantListings: 1},
+		{name: "expired", prepare: func() { time.Sleep(60 * time.Millisecond) },
+			wantResult: SizeCacheMiss, wantSize: 350, wantCount: 3, wantListings: 2},
+	}
+
+	for _, step := range steps {
+		if step.prepare != nil {
+			step.prepare()
+		}
+		size, count, result, err := cache.FolderSize(cfg, "bucket/svc/", false)
+		if err != nil {
+			t.Fatalf("%s: FolderSize error = %v", step.name, err)
+		}
+		if result != step.wantResult || size != step.wantSize || count != step.wantCount {
+			t.Errorf("%s: result = %s size %d count %d, want %s size %d count %d",
+				step.name, result, size, count, step.wantResult, step.wantSize, step.wantCount)
+		}
+		if _, _, listings := s3.requests(); listings != step.wantListings {
+			t.Errorf("%s: list requests = %d, want %d", step.name, listings, step.wantListings)
+		}
+	}
+}
+
+func TestSizeCacheLRUEviction(t *testing.T) {
+	s3 := newFakeS3(t)
+	for _, name := range []string{"a", "b", "c"} {
+		s3.put("bucket/"+name, 10, name)
+	}
+	cache := NewSizeCache(time.Minute, 2)
+	cfg := s3.config()
+
+	lookup := func(name string) string {
+		t.Helper()
+		_, result, err := cache.ObjectMetadata(cfg, "bucket/"+name, false)
+		if err != nil {
+			t.Fatalf("ObjectMetadata(%s): %v", name, err)
+		}
+		return result
+	}
+
+	lookup("a")
+	lookup("b")
+	lookup("a") // a를 최근 사용으로 이동 → 가장 오래된 항목은 b
+	lookup("c") // 최대 2개를 넘으므로 b 제거
+
+	tests := []struct {
+		name       string
+		wantResult string
+	}{
+		{name: "a", wantResult: SizeCacheHit},
+		{name: "c", wantResult: SizeCacheHit},
+		{name: "b", wantResult: SizeCacheMiss},
+	}
+	for _, tt := range tests {
+		if got := lookup(tt.name); got != tt.wantResult {
+			t.Errorf("lookup(%s) = %s, want %s", tt.name, got, tt.wantResult)
+		}
+	}
+	if cache.lru.Len() != 2 || len(cache.entries) != 2 {
+		t.Errorf("cache holds %d/%d entries, want 2", cache.lru.Len(), len(cache.entries))
+	}
+}
+
+func TestSizeCacheKeySeparatesServers(t *testing.T) {
+	first, second := newFakeS3(t), newFakeS3(t)
+	first.put("bucket/a", 10, "x")
+	second.put("bucket/a", 20, "y")
+	cache := NewSizeCache(time.Minute, 10)
+
+	for _, tt := range []struct {
+		s3       *fakeS3
+		wantSize int64
+	}{
+		{s3: first, wantSize: 10},
+		{s3: second, wantSize: 20},
+	} {
+		metadata, result, err := cache.ObjectMetadata(tt.s3.config(), "bucket/a", false)
+		if err != nil || result != SizeCacheMiss || metadata.Size != tt.wantSize {
+			t.Errorf("%s: result = %s size %v (%v), want miss size %d", tt.s3.server.URL, result, metadata, err, tt.wantSize)
+		}
+	}
+}
+
+func TestNilSizeCache(t *testing.T) {
+	s3 := newFakeS3(t)
+	s3.put("bucket/a", 10, "x")
+	var cache *SizeCache
+
+	for i := 0; i < 2; i++ {
+		metadata, result, err := cache.ObjectMetadata(s3.config(), "bucket/a", false)
+		if err != nil || result != "" || metadata.Size != 10 {
+			t.Fatalf("nil cache = (%v, %q, %v), want uncached size 10", metadata, result, err)
+		}
+	}
+	if heads, _, _ := s3.requests(); heads != 2 {
+		t.Errorf("HEAD requests = %d, want 2 without cache", heads)
+	}
+}
@@ -3,6 +3,9 @@ package services
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -10,7 +13,7 @@ import (
 func LoadTemplateRaw(provisionID string) (string, error) {
 	// 프로비저닝 ID의 하이픈을 언더스코어로 변환
 	filename := strings.ReplaceAll(provisionID, "-", "_")
-	filePath := fmt.Sprintf("./template/%s.yaml", filename)
+	filePath := filepath.Join(TemplateDir(), filename+".yaml")
 
 	// YAML 파일 읽기
 	data, err := ReadFile(filePath)
@@ -23,43 +26,181 @@ func LoadTemplateRaw(provisionID string) (string, error) {
 
 // ApplyServiceIDLabelsToYAML - YAML 문자열에 서비스 ID 라벨 적용
 // 템플릿 파일의 SERVICE_ID_PLACEHOLDER를 실제 서비스 ID로 교체
-// Kubernetes 리소스 이름에는 사용할 수 없는 문자 제거 (트레일링 슬래시)
+// Kubernetes 리소스 이름에는 사용할 수 없는 문자 제거 (트레일링 슬래시, 대문자)
 // UID가 있는 경우: SERVICE_ID_PLACEHOLDER-category-uid 형식
 // UID가 없는 경우: SERVICE_ID_PLACEHOLDER-category 형식
 func ApplyServiceIDLabelsToYAML(yamlStr string, serviceID string) string {
-	// Kubernetes 리소스 이름용: 트레일링 슬래시 제거
-	k8sSafeName := strings.TrimRight(serviceID, "/")
-	return strings.ReplaceAll(yamlStr, "SERVICE_ID_PLACEHOLDER", k8sSafeName)
+	return strings.ReplaceAll(yamlStr, "SERVICE_ID_PLACEHOLDER", k8sServiceID(serviceID))
+}
+
+// k8sServiceID - Kubernetes 리소스 이름용 서비스 ID (트레일링 슬래시 제거, 소문자)
+// MinIO 경로에는 원래 서비스 ID(폴더 모드의 트레일링 슬래시, 대소문자)를 그대로 사용
+func k8sServiceID(serviceID string) string {
+	return strings.ToLower(strings.TrimRight(serviceID, "/"))
 }
 
 // ApplyServiceIDLabelsWithUIDToYAML - YAML 문자열에 서비스 ID 라벨 적용 (UID 포함)
 // UID가 있는 경우: SERVICE_ID_PLACEHOLDER-category-uid 형식
 // UID가 없는 경우: SERVICE_ID_PLACEHOLDER-category 형식
 func ApplyServiceIDLabelsWithUIDToYAML(yamlStr string, serviceID string, category string, uid string) string {
-	// 트레일링 슬래시 제거
-	k8sSafeName := strings.TrimRight(serviceID, "/")
+	replacement := SparkApplicationName(serviceID, category, uid)
+
+	// SERVICE_ID_PLACEHOLDER를 포맷된 값으로 교체
+	return strings.ReplaceAll(yamlStr, "SERVICE_ID_PLACEHOLDER", replacement)
+}
+
+// SparkApplicationName - 서비스 ID/category/UID로 SparkApplication 이름 생성
+// ApplyServiceIDLabelsWithUIDToYAML과 lifecycle API(get/delete)에서 동일한 이름 규칙 사용
+// UID가 있는 경우: serviceID-category-uid, 없는 경우: serviceID-category
+func SparkApplicationName(serviceID string, category string, uid string) string {
+	k8sSafeName := k8sServiceID(serviceID)
 
 	// UID와 category에 따라 포맷 결정
-	var replacement string
 	if uid != "" {
-		replacement = fmt.Sprintf("%s-%s-%s", k8sSafeName, category, uid)
-	} else {
-		replacement = fmt.Sprintf("%s-%s", k8sSafeName, category)
+		return fmt.Sprintf("%s-%s-%s", k8sSafeName, category, uid)
 	}
+	return fmt.Sprintf("%s-%s", k8sSafeName, category)
+}
 
-	// SERVICE_ID_PLACEHOLDER를 포맷된 값으로 교체
-	return strings.ReplaceAll(yamlStr, "SERVICE_ID_PLACEHOLDER", replacement)
+// ApplyMetadataLabelsToYAML - metadata.labels에 라벨 추가
+// lifecycle API의 목록 조회(provision_id, category 필터)를 위해 사용
+// metadata.labels 섹션이 없으면 새로 생성, 이미 같은 키가 있으면 값을 교체
+func ApplyMetadataLabelsToYAML(yamlStr string, labels map[string]string) string {
+	return applyMetadataMapToYAML(yamlStr, "labels", labels)
+}
+
+// ApplyMetadataAnnotationsToYAML - metadata.annotations에 annotation 추가
+// metadata.annotations 섹션이 없으면 새로 생성, 이미 같은 키가 있으면 값을 교체
+func ApplyMetadataAnnotationsToYAML(yamlStr string, annotations map[string]string) string {
+	return applyMetadataMapToYAML(yamlStr, "annotations", annotations)
+}
+
+// ApplyNamespaceToYAML - metadata.namespace 교체 (category/region override)
+// metadata.namespace가 없으면 metadata 바로 아래에 추가, namespace가 비어 있으면 그대로 반환
+func ApplyNamespaceToYAML(yamlStr, namespace string) string {
+	if namespace == "" {
+		return yamlStr
+	}
+
+	lines := strings.Split(yamlStr, "\n")
+	metadataIdx := -1
+	for i, line := range lines {
+		if metadataIdx < 0 {
+			if line == "metadata:" {
+				metadataIdx = i
+			}
+			continue
+		}
+
+		// metadata 섹션 종료 (들여쓰기 없는 다음 키)
+		if line != "" && !strings.HasPrefix(line, " ") {
+			break
+		}
+		if strings.HasPrefix(line, "  namespace:") {
+			lines[i] = "  namespace: " + namespace
+			return strings.Join(lines, "\n")
+		}
+	}
+
+	if metadataIdx < 0 {
+		return yamlStr
+	}
+	result := make([]string, 0, len(lines)+1)
+	result = append(result, lines[:metadataIdx+1]...)
+	result = append(result, "  namespace: "+namespace)
+	result = append(result, lines[metadataIdx+1:]...)
+	return strings.Join(result, "\n")
 }
 
-// ApplyBuildNumberToYAML - YAML 문자열에 빌드 번호 적용
-// 템플릿 파일의 BUILD_NUMBER를 실제 빌드 번호로 교체
-// buildNumber는 minor 버전이며, 전체 버전은 major.minor.patch 형식으로 생성
-// major=4 (상수), patch=1 (상수)
-// 예: buildNumber="10" → "4.10.1"
-func ApplyBuildNumberToYAML(yamlStr string, buildNumber string) string {
-	// 전체 버전 생성: 4.{minor}.1
-	fullVersion := fmt.Sprintf("4.%s.1", buildNumber)
-	return strings.ReplaceAll(yamlStr, "BUILD_NUMBER", fullVersion)
+// ApplyConfigRevisionToYAML - 렌더링에 사용한 설정 revision을 annotation으로 기록 (revision이 0이면 그대로 반환)
+func ApplyConfigRevisionToYAML(yamlStr string, revision int64) string {
+	if revision <= 0 {
+		return yamlStr
+	}
+	return ApplyMetadataAnnotationsToYAML(yamlStr, map[string]string{
+		AnnotationConfigRevision: strconv.FormatInt(revision, 10),
+	})
+}
+
+// applyMetadataMapToYAML - metadata 아래 map 섹션(labels, annotations)에 키/값 추가
+func applyMetadataMapToYAML(yamlStr, section string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return yamlStr
+	}
+
+	// 라벨 순서를 고정하여 동일한 입력에 동일한 YAML 생성
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := strings.Split(yamlStr, "\n")
+	metadataIdx := -1
+	labelsIdx := -1
+	blockEnd := len(lines)
+
+	for i, line := range lines {
+		// 최상위 metadata: 섹션 찾기
+		if metadataIdx < 0 {
+			if line == "metadata:" {
+				metadataIdx = i
+			}
+			continue
+		}
+
+		// metadata 섹션 종료 (들여쓰기 없는 다음 키)
+		if line != "" && !strings.HasPrefix(line, " ") {
+			blockEnd = i
+			break
+		}
+
+		if strings.TrimSpace(line) == section+":" && labelsIdx < 0 && strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "   ") {
+			labelsIdx = i
+		}
+	}
+
+	if metadataIdx < 0 {
+		return yamlStr
+	}
+
+	// 기존 라벨 중 같은 키는 교체
+	remaining := make([]string, 0, len(keys))
+	for _, key := range keys {
+		replaced := false
+		if labelsIdx >= 0 {
+			for i := labelsIdx + 1; i < blockEnd; i++ {
+				trimmed := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(lines[i], "    ") {
+					break
+				}
+				if strings.HasPrefix(trimmed, key+":") {
+					lines[i] = fmt.Sprintf("    %s: \"%s\"", key, labels[key])
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced {
+			remaining = append(remaining, key)
+		}
+	}
+
+	newLines := make([]string, 0, len(remaining)+1)
+	insertAt := labelsIdx + 1
+	if labelsIdx < 0 {
+		newLines = append(newLines, "  "+section+":")
+		insertAt = metadataIdx + 1
+	}
+	for _, key := range remaining {
+		newLines = append(newLines, fmt.Sprintf("    %s: \"%s\"", key, labels[key]))
+	}
+
+	result := make([]string, 0, len(lines)+len(newLines))
+	result = append(result, lines[:insertAt]...)
+	result = append(result, newLines...)
+	result = append(result, lines[insertAt:]...)
+	return strings.Join(result, "\n")
 }
 
 // ApplyArgumentsToYAML - YAML의 arguments 섹션을 사용자 제공 arguments로 교체
@@ -2,9 +2,38 @@ package services
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 // ReadFile - 파일 읽기 헬퍼 함수
 func ReadFile(filePath string) ([]byte, error) {
 	return os.ReadFile(filePath)
 }
+
+// GetEnvInt - 환경 변수를 정수로 읽기 (없거나 잘못된 값이면 기본값)
+func GetEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// GetEnvBool - 환경 변수를 bool로 읽기 (예: "true", "1", 없거나 잘못된 값이면 기본값)
+func GetEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// GetEnvDuration - 환경 변수를 time.Duration으로 읽기 (예: "10m", 없거나 잘못된 값이면 기본값)
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SparkApplication 종료 상태 (Spark Operator applicationState.state)
+	StateCompleted        = "COMPLETED"
+	StateFailed           = "FAILED"
+	StateSubmissionFailed = "SUBMISSION_FAILED"
+
+	// watchRetryInterval - watch 연결이 끊긴 경우 재연결 대기 시간
+	watchRetryInterval = 2 * time.Second
+)
+
+// SparkApplicationEvent - SparkApplication 상태 전이 이벤트
+type SparkApplicationEvent struct {
+	Type            string    `json:"type"` // state, deleted, error
+	Name            string    `json:"name"`
+	Namespace       string    `json:"namespace"`
+	State           string    `json:"state"`
+	PreviousState   string    `json:"previous_state,omitempty"`
+	ErrorMessage    string    `json:"error_message,omitempty"`
+	DriverPodName   string    `json:"driver_pod_name,omitempty"`
+	SubmissionTime  string    `json:"submission_time,omitempty"`
+	TerminationTime string    `json:"termination_time,omitempty"`
+	Timestamp       time.Time `json:"timestamp"` // 서비스에서 상태 변화를 관찰한 시각
+	Terminal        bool      `json:"terminal"`  // 종료 상태 여부 (스트림 종료)
+}
+
+// IsTerminalState - SparkApplication이 종료 상태인지 확인
+func IsTerminalState(state string) bool {
+	switch state {
+	case StateCompleted, StateFailed, StateSubmissionFailed:
+		return true
+	}
+	return false
+}
+
+// WatchSparkApplication - SparkApplication 상태 전이를 watch하여 이벤트 채널로 전달
+// 첫 이벤트는 현재 상태이며, 이후 상태나 driver pod가 바뀔 때마다 이벤트 전송
+// 종료 상태 도달, 삭제, ctx 취소 시 채널을 닫음
+// SparkApplication이 존재하지 않으면 즉시 오류 반환 (IsNotFoundError로 확인 가능)
+func WatchSparkApplication(ctx context.Context, namespace, name string) (<-chan SparkApplicationEvent, error) {
+	// 클라이언트 초기화
+	if err := initK8sClient(); err != nil {
+		return nil, err
+	}
+
+	current, err := getSparkApplication(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan SparkApplicationEvent, 8)
+	go func() {
+		defer close(events)
+
+		last := newSparkApplicationEvent(current, "")
+		if !sendEvent(ctx, events, last) || last.Terminal {
+			return
+		}
+
+		resourceVersion := current.GetResourceVersion()
+		for {
+			next, done := watchUntilClosed(ctx, events, namespace, name, resourceVersion, &last)
+			if done {
+				return
+			}
+			resourceVersion = next
+
+			// watch 연결 종료(타임아웃 등) 시 재연결
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchRetryInterval):
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// watchUntilClosed - watch 한 번을 열어 이벤트를 전달, 스트림을 끝내야 하면 done=true
+// 반환되는 resourceVersion은 재연결 시 이어서 watch할 위치
+func watchUntilClosed(ctx context.Context, events chan<- SparkApplicationEvent, namespace, name, resourceVersion string, last *SparkApplicationEvent) (string, bool) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(sparkApplicationGVK.GroupVersion().WithKind(sparkApplicationGVK.Kind + "List"))
+
+	watcher, err := k8sClient.Watch(ctx, list, &client.ListOptions{
+		Namespace:     namespace,
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", name),
+		Raw:           &metav1.ListOptions{ResourceVersion: resourceVersion},
+	})
+	if err != nil {
+		sendEvent(ctx, events, newWatchErrorEvent(namespace, name, fmt.Errorf("SparkApplication watch 실패: %w", err)))
+		return resourceVersion, true
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, true
+		case raw, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, false
+			}
+
+			switch raw.Type {
+			case watch.Error:
+				// resourceVersion 만료(410 Gone) 등 - 현재 상태를 다시 조회해 끊긴 동안의 변화를 전달하고 이어서 watch
+				log.Printf("SparkApplication watch 오류: %s/%s", namespace, name)
+				current, err := getSparkApplication(ctx, namespace, name)
+				if IsNotFoundError(err) {
+					sendDeletedEvent(ctx, events, last)
+					return resourceVersion, true
+				}
+				if err != nil {
+					sendEvent(ctx, events, newWatchErrorEvent(namespace, name, err))
+					return resourceVersion, true
+				}
+				if sendStateChange(ctx, events, current, last) {
+					return current.GetResourceVersion(), true
+				}
+				return current.GetResourceVersion(), false
+			case watch.Deleted:
+				sendDeletedEvent(ctx, events, last)
+				return resourceVersion, true
+			case watch.Added, watch.Modified:
+				u, ok := raw.Object.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				resourceVersion = u.GetResourceVersion()
+				if sendStateChange(ctx, events, u, last) {
+					return resourceVersion, true
+				}
+			}
+		}
+	}
+}
+
+// sendStateChange - 상태나 driver pod가 last와 다르면 이벤트를 전송하고 last 갱신
+// 종료 상태에 도달했거나 ctx가 취소되어 스트림을 끝내야 하면 true
+func sendStateChange(ctx context.Context, events chan<- SparkApplicationEvent, u *unstructured.Unstructured, last *SparkApplicationEvent) bool {
+	event := newSparkApplicationEvent(u, last.State)
+	if event.State == last.State && event.DriverPodName == last.DriverPodName {
+		return false
+	}
+	*last = event
+	return !sendEvent(ctx, events, event) || event.Terminal
+}
+
+// sendDeletedEvent - 마지막 상태로 삭제 이벤트 전송 (스트림 종료)
+func sendDeletedEvent(ctx context.Context, events chan<- SparkApplicationEvent, last *SparkApplicationEvent) {
+	event := *last
+	event.Type = "deleted"
+	event.PreviousState = last.State
+	event.Timestamp = time.Now()
+	event.Terminal = true
+	sendEvent(ctx, events, event)
+}
+
+// newSparkApplicationEvent - SparkApplication 객체로 상태 이벤트 생성
+func newSparkApplicationEvent(u *unstructured.Unstructured, previousState string) SparkApplicationEvent {
+	summary := summarizeSparkApplication(u)
+	return SparkApplicationEvent{
+		Type:            "state",
+		Name:            summary.Name,
+		Namespace:       summary.Namespace,
+		State:           summary.State,
+		PreviousState:   previousState,
+		ErrorMessage:    summary.ErrorMessage,
+		DriverPodName:   summary.DriverPodName,
+		SubmissionTime:  summary.SubmissionTime,
+		TerminationTime: summary.TerminationTime,
+		Timestamp:       time.Now(),
+		Terminal:        IsTerminalState(summary.State),
+	}
+}
+
+// newWatchErrorEvent - watch 실패 이벤트 생성 (스트림 종료)
+func newWatchErrorEvent(namespace, name string, err error) SparkApplicationEvent {
+	return SparkApplicationEvent{
+		Type:         "error",
+		Name:         name,
+		Namespace:    namespace,
+		ErrorMessage: err.Error(),
+		Timestamp:    time.Now(),
+		Terminal:     true,
+	}
+}
+
+// sendEvent - ctx가 취소되지 않았으면 이벤트 전송
+func sendEvent(ctx context.Context, events chan<- SparkApplicationEvent, event SparkApplicationEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- event:
+		return true
+	}
+}